@@ -0,0 +1,12 @@
+data "azurex_benefit_recommendations" "example" {
+  scope            = "/subscriptions/00000000-0000-0000-0000-000000000000"
+  look_back_period = "Last30Days"
+  term             = "P1Y"
+}
+
+output "recommended_commitments" {
+  value = {
+    for recommendation in data.azurex_benefit_recommendations.example.recommendations :
+    recommendation.arm_sku_name => "${recommendation.commitment_amount} ${recommendation.currency_code}/${recommendation.commitment_granularity}, saving ${recommendation.savings_percentage}%"
+  }
+}
@@ -0,0 +1,14 @@
+data "azurex_cost_alerts" "budgets" {
+  scope = "/subscriptions/00000000-0000-0000-0000-000000000000"
+  types = ["Budget", "BudgetForecast"]
+}
+
+data "azurex_cost_alerts" "all" {
+  scope      = "/subscriptions/00000000-0000-0000-0000-000000000000"
+  statuses   = ["Active", "Dismissed", "Resolved"]
+  categories = ["Cost", "Usage"]
+}
+
+output "active_budget_alerts" {
+  value = [for alert in data.azurex_cost_alerts.budgets.alerts : "${alert.name}: ${alert.current_spend} of ${alert.amount} ${alert.unit}"]
+}
@@ -0,0 +1,14 @@
+data "azurex_cost_details_report" "last_month" {
+  scope  = "/subscriptions/00000000-0000-0000-0000-000000000000"
+  metric = "AmortizedCost"
+
+  time_period = {
+    from = "2030-01-01T00:00:00Z"
+    to   = "2030-01-31T00:00:00Z"
+  }
+}
+
+output "cost_details_blobs" {
+  value     = data.azurex_cost_details_report.last_month.blob_urls
+  sensitive = true
+}
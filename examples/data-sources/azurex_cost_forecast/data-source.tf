@@ -0,0 +1,27 @@
+data "azurex_cost_forecast" "this_year" {
+  scope               = "/subscriptions/00000000-0000-0000-0000-000000000000"
+  granularity         = "Monthly"
+  include_actual_cost = true
+
+  time_period = {
+    from = "2030-01-01T00:00:00Z"
+    to   = "2030-12-31T00:00:00Z"
+  }
+
+  aggregation = {
+    totalCost = {
+      column = "Cost"
+    }
+  }
+
+  filter = {
+    tags = [
+      { name = "environment", values = ["production"] },
+    ]
+  }
+}
+
+# Derive the budget from the forecast plus 10% headroom instead of hardcoding it
+locals {
+  budget = ceil(data.azurex_cost_forecast.this_year.totals["totalCost"] * 1.1)
+}
@@ -0,0 +1,44 @@
+data "azurex_cost_query" "month_to_date" {
+  scope     = "/subscriptions/00000000-0000-0000-0000-000000000000"
+  timeframe = "MonthToDate"
+
+  aggregation = {
+    totalCost = {
+      column = "Cost"
+    }
+  }
+}
+
+data "azurex_cost_query" "by_service" {
+  scope       = "/subscriptions/00000000-0000-0000-0000-000000000000"
+  type        = "AmortizedCost"
+  timeframe   = "TheLastMonth"
+  granularity = "Daily"
+
+  aggregation = {
+    totalCost = {
+      column = "Cost"
+    }
+  }
+
+  grouping = [
+    { type = "Dimension", name = "ServiceName" },
+  ]
+
+  filter = {
+    dimensions = [
+      { name = "ResourceGroupName", values = ["app", "data"] },
+    ]
+    tags = [
+      { name = "environment", values = ["production"] },
+    ]
+  }
+}
+
+# Fail the plan once month-to-date spend exceeds the budget
+check "budget" {
+  assert {
+    condition     = data.azurex_cost_query.month_to_date.totals["totalCost"] < 5000
+    error_message = "Month-to-date spend exceeds 5000."
+  }
+}
@@ -0,0 +1,9 @@
+data "azurex_subscription_tags" "example" {}
+
+data "azurex_subscription_tags" "landing_zone" {
+  subscription_id = "00000000-0000-0000-0000-000000000000"
+}
+
+output "cost_center" {
+  value = data.azurex_subscription_tags.example.tags["CostCenter"]
+}
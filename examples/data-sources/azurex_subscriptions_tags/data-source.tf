@@ -0,0 +1,7 @@
+data "azurex_subscriptions_tags" "all" {}
+
+output "owners" {
+  value = {
+    for s in data.azurex_subscriptions_tags.all.subscriptions : s.display_name => lookup(s.tags, "Owner", null)
+  }
+}
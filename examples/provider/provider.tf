@@ -1,3 +1,12 @@
 provider "azurex" {
   subscription_id = "00000000-0000-0000-0000-0000000000000"
+
+  default_tags = {
+    CostCenter = "1234"
+    Owner      = "platform"
+  }
+
+  ignore_tags = {
+    key_prefixes = ["inherited-"]
+  }
 }
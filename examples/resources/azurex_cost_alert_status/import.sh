@@ -0,0 +1 @@
+terraform import azurex_cost_alert_status.budget /subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.CostManagement/alerts/00000000-0000-0000-0000-000000000001
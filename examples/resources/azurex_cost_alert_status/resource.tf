@@ -0,0 +1,5 @@
+resource "azurex_cost_alert_status" "budget" {
+  scope  = "/subscriptions/00000000-0000-0000-0000-000000000000"
+  name   = "00000000-0000-0000-0000-000000000001"
+  status = "Dismissed"
+}
@@ -0,0 +1,30 @@
+resource "azurex_cost_export" "example" {
+  name               = "monthly-actual-cost"
+  scope              = "/subscriptions/00000000-0000-0000-0000-000000000000"
+  type               = "ActualCost"
+  timeframe          = "MonthToDate"
+  granularity        = "Daily"
+  storage_account_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/finops/providers/Microsoft.Storage/storageAccounts/finopsexports"
+  container          = "exports"
+  root_folder_path   = "subscriptions/example"
+
+  schedule = {
+    recurrence = "Daily"
+    from       = "2030-01-01T00:00:00Z"
+  }
+}
+
+resource "azurex_cost_export" "last_quarter" {
+  name               = "last-quarter"
+  scope              = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example"
+  type               = "AmortizedCost"
+  timeframe          = "Custom"
+  storage_account_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/finops/providers/Microsoft.Storage/storageAccounts/finopsexports"
+  container          = "exports"
+  run_now            = true
+
+  time_period = {
+    from = "2030-01-01T00:00:00Z"
+    to   = "2030-03-31T00:00:00Z"
+  }
+}
@@ -0,0 +1 @@
+terraform import azurex_cost_scheduled_action.anomalies /subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.CostManagement/scheduledActions/daily-anomalies
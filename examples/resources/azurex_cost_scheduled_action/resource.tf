@@ -0,0 +1,40 @@
+resource "azurex_cost_scheduled_action" "weekly_costs" {
+  name         = "weekly-costs-by-service"
+  scope        = "/subscriptions/00000000-0000-0000-0000-000000000000"
+  display_name = "Weekly costs by service"
+  view_id      = "/providers/Microsoft.CostManagement/views/ms:CostByService"
+  file_formats = ["Csv"]
+
+  notification = {
+    to      = ["finops@example.com"]
+    subject = "Weekly costs by service"
+  }
+
+  schedule = {
+    frequency    = "Weekly"
+    days_of_week = ["Monday"]
+    hour_of_day  = 8
+    start_date   = "2030-01-01T00:00:00Z"
+    end_date     = "2031-01-01T00:00:00Z"
+  }
+}
+
+resource "azurex_cost_scheduled_action" "anomalies" {
+  name               = "daily-anomalies"
+  scope              = "/subscriptions/00000000-0000-0000-0000-000000000000"
+  kind               = "InsightAlert"
+  display_name       = "Daily cost anomalies"
+  view_id            = "/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.CostManagement/views/ms:DailyAnomalyByResourceGroup"
+  notification_email = "finops@example.com"
+
+  notification = {
+    to      = ["finops@example.com", "platform@example.com"]
+    subject = "Cost anomaly detected"
+  }
+
+  schedule = {
+    frequency  = "Daily"
+    start_date = "2030-01-01T00:00:00Z"
+    end_date   = "2031-01-01T00:00:00Z"
+  }
+}
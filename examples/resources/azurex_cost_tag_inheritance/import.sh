@@ -0,0 +1 @@
+terraform import azurex_cost_tag_inheritance.example /subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.CostManagement/settings/taginheritance
@@ -0,0 +1,19 @@
+resource "azurex_cost_tag_inheritance" "example" {
+  scope                 = "/subscriptions/00000000-0000-0000-0000-000000000000"
+  prefer_container_tags = true
+}
+
+resource "azurex_cost_tag_inheritance" "billing_profile" {
+  scope = "/providers/Microsoft.Billing/billingAccounts/00000000-0000-0000-0000-000000000000:00000000-0000-0000-0000-000000000000_2019-05-31/billingProfiles/AAAA-BBBB-CCC-DDD"
+}
+
+# azurex_subscription_tags manages tag inheritance by default, leave the
+# setting to azurex_cost_tag_inheritance on the same subscription.
+resource "azurex_subscription_tags" "example" {
+  subscription_id = "00000000-0000-0000-0000-000000000000"
+  inherit_tags    = false
+
+  tags = {
+    "CostCenter" = "1234"
+  }
+}
@@ -0,0 +1,4 @@
+terraform import azurex_cost_view.by_service /subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.CostManagement/views/costs-by-service
+
+# Views shared with the whole tenant have no scope
+terraform import azurex_cost_view.tenant /providers/Microsoft.CostManagement/views/tenant-costs
@@ -0,0 +1,48 @@
+resource "azurex_cost_view" "by_service" {
+  name         = "costs-by-service"
+  scope        = "/subscriptions/00000000-0000-0000-0000-000000000000"
+  display_name = "Costs by service"
+  chart        = "StackedColumn"
+  accumulated  = true
+  metric       = "ActualCost"
+  timeframe    = "MonthToDate"
+  granularity  = "Daily"
+
+  aggregation = {
+    totalCost = {
+      column = "PreTaxCost"
+    }
+  }
+
+  grouping = [
+    { type = "Dimension", name = "ServiceName" },
+  ]
+
+  kpis = [
+    { type = "Forecast" },
+  ]
+
+  pivots = [
+    { type = "Dimension", name = "ResourceGroupName" },
+    { type = "TagKey", name = "team" },
+  ]
+}
+
+resource "azurex_cost_view" "platform" {
+  name         = "platform-by-team"
+  scope        = "/providers/Microsoft.Management/managementGroups/platform"
+  display_name = "Platform costs by team"
+  chart        = "GroupedColumn"
+  timeframe    = "YearToDate"
+  granularity  = "Monthly"
+
+  aggregation = {
+    totalCost = {
+      column = "PreTaxCost"
+    }
+  }
+
+  grouping = [
+    { type = "TagKey", name = "team" },
+  ]
+}
@@ -0,0 +1 @@
+terraform import azurex_resource_group_tags.example /subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example
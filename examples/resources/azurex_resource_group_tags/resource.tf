@@ -0,0 +1,13 @@
+resource "azurex_resource_group_tags" "example" {
+  resource_group_name = "example"
+
+  # Tags added by Cost Management tag inheritance or an Azure Policy
+  # "inherit a tag" assignment.
+  ignore_tag_keys     = ["CostCenter"]
+  ignore_tag_prefixes = ["policy-"]
+
+  tags = {
+    "Environment" = "Production"
+    "Owner"       = "DevOps Team"
+  }
+}
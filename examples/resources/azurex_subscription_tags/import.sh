@@ -0,0 +1 @@
+terraform import azurex_subscription_tags.example /subscriptions/00000000-0000-0000-0000-000000000000
@@ -1,6 +1,22 @@
-resource "azurex_subscription_tags" {
+resource "azurex_subscription_tags" "example" {
   tags = {
     "Environment" = "Production"
     "Owner"       = "DevOps Team"
   }
-}
\ No newline at end of file
+}
+
+resource "azurex_subscription_tags" "landing_zone" {
+  subscription_id = "00000000-0000-0000-0000-000000000000"
+
+  tags = {
+    "CostCenter" = "1234"
+  }
+}
+
+resource "azurex_subscription_tags" "shared" {
+  mode = "merge"
+
+  tags = {
+    "Owner" = "Platform Team"
+  }
+}
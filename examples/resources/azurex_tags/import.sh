@@ -0,0 +1 @@
+terraform import azurex_tags.example /subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Resources/tags/default
@@ -0,0 +1,17 @@
+resource "azurex_tags" "resource_group" {
+  scope = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example"
+
+  tags = {
+    "Environment" = "Production"
+    "Owner"       = "DevOps Team"
+  }
+}
+
+resource "azurex_tags" "management_group" {
+  scope = "/providers/Microsoft.Management/managementGroups/platform"
+  mode  = "merge"
+
+  tags = {
+    "CostCenter" = "1234"
+  }
+}
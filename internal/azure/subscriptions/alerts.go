@@ -0,0 +1,271 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package subscriptions
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+// AlertsClient contains the methods for the Alerts group.
+// Don't use this type directly, use NewAlertsClient() instead.
+type AlertsClient struct {
+	internal *arm.Client
+}
+
+// NewAlertsClient creates a new instance of AlertsClient with the specified values.
+//   - credential - used to authorize requests. Usually a credential from azidentity.
+//   - options - pass nil to accept the default values.
+func NewAlertsClient(credential azcore.TokenCredential, options *arm.ClientOptions) (*AlertsClient, error) {
+	cl, err := arm.NewClient(moduleName+".AlertsClient", moduleVersion, credential, options)
+	if err != nil {
+		return nil, err
+	}
+	client := &AlertsClient{
+		internal: cl,
+	}
+	return client, nil
+}
+
+// Alert - An individual alert.
+type Alert struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+	Type string `json:"type,omitempty"`
+
+	// eTag of the resource.
+	ETag string `json:"eTag,omitempty"`
+
+	// Alert properties.
+	Properties AlertProperties `json:"properties"`
+}
+
+// AlertProperties - Alert properties.
+type AlertProperties struct {
+	// dateTime in which alert was closed
+	CloseTime string `json:"closeTime,omitempty"`
+
+	// related budget
+	CostEntityID string `json:"costEntityId,omitempty"`
+
+	// dateTime in which alert was created
+	CreationTime string `json:"creationTime,omitempty"`
+
+	// defines the type of alert
+	Definition *AlertPropertiesDefinition `json:"definition,omitempty"`
+
+	// Alert description
+	Description string `json:"description,omitempty"`
+
+	// Alert details
+	Details *AlertPropertiesDetails `json:"details,omitempty"`
+
+	// dateTime in which alert was last modified
+	ModificationTime string `json:"modificationTime,omitempty"`
+
+	// Source of alert
+	Source AlertSource `json:"source,omitempty"`
+
+	// alert status
+	Status AlertStatus `json:"status,omitempty"`
+
+	// dateTime in which the alert status was last modified
+	StatusModificationTime string `json:"statusModificationTime,omitempty"`
+
+	// User who last modified the alert
+	StatusModificationUserName string `json:"statusModificationUserName,omitempty"`
+}
+
+// AlertPropertiesDefinition - defines the type of alert
+type AlertPropertiesDefinition struct {
+	// Alert category
+	Category AlertCategory `json:"category,omitempty"`
+
+	// Criteria that triggered alert
+	Criteria AlertCriteria `json:"criteria,omitempty"`
+
+	// type of alert
+	Type AlertType `json:"type,omitempty"`
+}
+
+// AlertPropertiesDetails - Alert details
+type AlertPropertiesDetails struct {
+	// budget threshold amount
+	Amount float64 `json:"amount,omitempty"`
+
+	// list of emails to contact
+	ContactEmails []string `json:"contactEmails,omitempty"`
+
+	// list of action groups to broadcast to
+	ContactGroups []string `json:"contactGroups,omitempty"`
+
+	// list of contact roles
+	ContactRoles []string `json:"contactRoles,omitempty"`
+
+	// current spend
+	CurrentSpend float64 `json:"currentSpend,omitempty"`
+
+	// operator used to compare currentSpend with amount
+	Operator AlertOperator `json:"operator,omitempty"`
+
+	// datetime of periodStartDate
+	PeriodStartDate string `json:"periodStartDate,omitempty"`
+
+	// notification threshold percentage as a decimal which activated this alert
+	Threshold float64 `json:"threshold,omitempty"`
+
+	// Type of timegrain cadence
+	TimeGrainType AlertTimeGrainType `json:"timeGrainType,omitempty"`
+
+	// notificationId that triggered this alert
+	TriggeredBy string `json:"triggeredBy,omitempty"`
+
+	// unit of currency being used
+	Unit string `json:"unit,omitempty"`
+}
+
+// DismissAlertPayload - The request payload to update an alert
+type DismissAlertPayload struct {
+	// Alert properties, only the status is updated.
+	Properties AlertProperties `json:"properties"`
+}
+
+// AlertsResult - Result of alerts.
+type AlertsResult struct {
+	// URL to get the next set of alerts results if there are any.
+	NextLink string `json:"nextLink,omitempty"`
+
+	// List of alerts.
+	Value []Alert `json:"value,omitempty"`
+}
+
+// List the alerts for scope defined, following nextLink until every alert
+// has been read.
+//   - scope - The scope associated with alerts operations, e.g. 'subscriptions/{subscriptionId}'.
+func (client *AlertsClient) List(ctx context.Context, scope string) ([]Alert, error) {
+	urlPath := "/{scope}/providers/Microsoft.CostManagement/alerts"
+	urlPath = strings.ReplaceAll(urlPath, "{scope}", strings.Trim(scope, "/"))
+	req, err := client.newListRequest(ctx, runtime.JoinPaths(client.internal.Endpoint(), urlPath))
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := make([]Alert, 0)
+	for req != nil {
+		resp, err := client.internal.Pipeline().Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if !runtime.HasStatusCode(resp, http.StatusOK) {
+			return nil, newResponseError(resp)
+		}
+
+		var page AlertsResult
+		if err := runtime.UnmarshalAsJSON(resp, &page); err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, page.Value...)
+
+		req = nil
+		if page.NextLink != "" {
+			if req, err = client.newListRequest(ctx, page.NextLink); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return alerts, nil
+}
+
+// Get the alert for the scope by alert ID.
+//   - scope - The scope associated with alerts operations, e.g. 'subscriptions/{subscriptionId}'.
+//   - alertID - Alert ID, the name of the alert.
+func (client *AlertsClient) Get(ctx context.Context, scope string, alertID string) (Alert, error) {
+	req, err := client.newRequest(ctx, http.MethodGet, scope, alertID)
+	if err != nil {
+		return Alert{}, err
+	}
+
+	resp, err := client.internal.Pipeline().Do(req)
+	if err != nil {
+		return Alert{}, err
+	}
+
+	if !runtime.HasStatusCode(resp, http.StatusOK) {
+		return Alert{}, newResponseError(resp)
+	}
+
+	var alert Alert
+	if err := runtime.UnmarshalAsJSON(resp, &alert); err != nil {
+		return Alert{}, err
+	}
+	return alert, nil
+}
+
+// Dismiss sets the status of the alert, e.g. to Dismissed or back to Active.
+//   - scope - The scope associated with alerts operations, e.g. 'subscriptions/{subscriptionId}'.
+//   - alertID - Alert ID, the name of the alert.
+//   - status - The new status of the alert.
+func (client *AlertsClient) Dismiss(ctx context.Context, scope string, alertID string, status AlertStatus) (Alert, error) {
+	req, err := client.newRequest(ctx, http.MethodPatch, scope, alertID)
+	if err != nil {
+		return Alert{}, err
+	}
+	if err := runtime.MarshalAsJSON(req, DismissAlertPayload{Properties: AlertProperties{Status: status}}); err != nil {
+		return Alert{}, err
+	}
+
+	resp, err := client.internal.Pipeline().Do(req)
+	if err != nil {
+		return Alert{}, err
+	}
+
+	if !runtime.HasStatusCode(resp, http.StatusOK) {
+		return Alert{}, newResponseError(resp)
+	}
+
+	var alert Alert
+	if err := runtime.UnmarshalAsJSON(resp, &alert); err != nil {
+		return Alert{}, err
+	}
+	return alert, nil
+}
+
+func (client *AlertsClient) newListRequest(ctx context.Context, endpoint string) (*policy.Request, error) {
+	req, err := runtime.NewRequest(ctx, http.MethodGet, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	reqQP := req.Raw().URL.Query()
+	reqQP.Set("api-version", "2023-03-01")
+	req.Raw().URL.RawQuery = reqQP.Encode()
+	req.Raw().Header["Accept"] = []string{"application/json"}
+	return req, nil
+}
+
+func (client *AlertsClient) newRequest(ctx context.Context, method string, scope string, alertID string) (*policy.Request, error) {
+	urlPath := "/{scope}/providers/Microsoft.CostManagement/alerts/{alertId}"
+	if alertID == "" {
+		return nil, errors.New("parameter alertID cannot be empty")
+	}
+	urlPath = strings.ReplaceAll(urlPath, "{scope}", strings.Trim(scope, "/"))
+	urlPath = strings.ReplaceAll(urlPath, "{alertId}", url.PathEscape(alertID))
+	req, err := runtime.NewRequest(ctx, method, runtime.JoinPaths(client.internal.Endpoint(), urlPath))
+	if err != nil {
+		return nil, err
+	}
+	reqQP := req.Raw().URL.Query()
+	reqQP.Set("api-version", "2023-03-01")
+	req.Raw().URL.RawQuery = reqQP.Encode()
+	req.Raw().Header["Accept"] = []string{"application/json"}
+	return req, nil
+}
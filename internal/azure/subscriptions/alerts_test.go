@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package subscriptions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+)
+
+func TestAlertsClient_List(t *testing.T) {
+	var serverURL string
+	options := newTestClientOptions(t, func(w http.ResponseWriter, r *http.Request) {
+		if want := "/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.CostManagement/alerts"; r.Method != http.MethodGet || r.URL.Path != want {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("$skiptoken") == "" {
+			_, _ = fmt.Fprintf(w, `{"nextLink": "%s%s?$skiptoken=1", "value": [{"name": "budget-80", "properties": {"status": "Active", "definition": {"type": "Budget", "category": "Cost", "criteria": "CostThresholdExceeded"}, "details": {"amount": 100, "currentSpend": 85.5}}}]}`, serverURL, r.URL.Path)
+			return
+		}
+		_, _ = w.Write([]byte(`{"value": [{"name": "quota", "properties": {"status": "Dismissed", "definition": {"type": "Quota", "category": "Usage"}}}]}`))
+	})
+	serverURL = options.Cloud.Services[cloud.ResourceManager].Endpoint
+
+	client, err := NewAlertsClient(staticCredential{}, options)
+	if err != nil {
+		t.Fatalf("creating alerts client: %s", err)
+	}
+
+	got, err := client.List(context.Background(), "/subscriptions/00000000-0000-0000-0000-000000000000")
+	if err != nil {
+		t.Fatalf("listing alerts: %s", err)
+	}
+	if len(got) != 2 || got[0].Properties.Definition.Criteria != AlertCriteriaCostThresholdExceeded || got[0].Properties.Details.CurrentSpend != 85.5 || got[1].Properties.Status != AlertStatusDismissed {
+		t.Fatalf("unexpected alerts: %+v", got)
+	}
+}
+
+func TestAlertsClient_Dismiss(t *testing.T) {
+	client, err := NewAlertsClient(staticCredential{}, newTestClientOptions(t, func(w http.ResponseWriter, r *http.Request) {
+		if want := "/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.CostManagement/alerts/budget-80"; r.Method != http.MethodPatch || r.URL.Path != want {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		var body map[string]map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decoding request: %s", err)
+		}
+		if len(body["properties"]) != 1 || body["properties"]["status"] != "Dismissed" {
+			t.Errorf("expected only the status to be sent, got %v", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name": "budget-80", "properties": {"status": "Dismissed"}}`))
+	}))
+	if err != nil {
+		t.Fatalf("creating alerts client: %s", err)
+	}
+
+	got, err := client.Dismiss(context.Background(), "/subscriptions/00000000-0000-0000-0000-000000000000", "budget-80", AlertStatusDismissed)
+	if err != nil {
+		t.Fatalf("dismissing alert: %s", err)
+	}
+	if got.Properties.Status != AlertStatusDismissed {
+		t.Fatalf("unexpected alert: %+v", got)
+	}
+}
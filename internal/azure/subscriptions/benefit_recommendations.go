@@ -0,0 +1,190 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package subscriptions
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+// BenefitRecommendationsClient contains the methods for the BenefitRecommendations group.
+// Don't use this type directly, use NewBenefitRecommendationsClient() instead.
+type BenefitRecommendationsClient struct {
+	internal *arm.Client
+}
+
+// NewBenefitRecommendationsClient creates a new instance of BenefitRecommendationsClient with the specified values.
+//   - credential - used to authorize requests. Usually a credential from azidentity.
+//   - options - pass nil to accept the default values.
+func NewBenefitRecommendationsClient(credential azcore.TokenCredential, options *arm.ClientOptions) (*BenefitRecommendationsClient, error) {
+	cl, err := arm.NewClient(moduleName+".BenefitRecommendationsClient", moduleVersion, credential, options)
+	if err != nil {
+		return nil, err
+	}
+	client := &BenefitRecommendationsClient{
+		internal: cl,
+	}
+	return client, nil
+}
+
+// BenefitRecommendationModel - benefit plan recommendation details.
+type BenefitRecommendationModel struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+	Type string `json:"type,omitempty"`
+
+	// Kind of the recommendation, e.g. SavingsPlan.
+	Kind BenefitKind `json:"kind,omitempty"`
+
+	// The properties of the benefit recommendations.
+	Properties BenefitRecommendationProperties `json:"properties"`
+}
+
+// BenefitRecommendationProperties - The properties of the benefit recommendations. Single scope
+// recommendations additionally carry the subscription and resource group they are for.
+type BenefitRecommendationProperties struct {
+	// Benefit scope. For example, Single or Shared.
+	Scope Scope `json:"scope,omitempty"`
+
+	// Term period of the benefit. For example, P1Y or P3Y.
+	Term Term `json:"term,omitempty"`
+
+	// The number of days of usage evaluated for computing the recommendations.
+	LookBackPeriod LookBackPeriod `json:"lookBackPeriod,omitempty"`
+
+	// The grain of the proposed commitment amount.
+	CommitmentGranularity Grain `json:"commitmentGranularity,omitempty"`
+
+	// READ-ONLY; An ARM SKU name.
+	ArmSKUName string `json:"armSkuName,omitempty"`
+
+	// READ-ONLY; The current cost without benefit; corresponds to 'totalHours' * (cost of each hour without benefit).
+	CostWithoutBenefit float64 `json:"costWithoutBenefit,omitempty"`
+
+	// READ-ONLY; An ISO 4217 currency code identifier for the costs and savings
+	CurrencyCode string `json:"currencyCode,omitempty"`
+
+	// READ-ONLY; The first usage date used for looking back for computing the recommendations.
+	FirstConsumptionDate *time.Time `json:"firstConsumptionDate,omitempty"`
+
+	// READ-ONLY; The last usage date used for looking back for computing the recommendations.
+	LastConsumptionDate *time.Time `json:"lastConsumptionDate,omitempty"`
+
+	// READ-ONLY; The number of hours that benefit is evaluated for.
+	TotalHours int32 `json:"totalHours,omitempty"`
+
+	// The details of the proposed recommendation.
+	RecommendationDetails *AllSavingsBenefitDetails `json:"recommendationDetails,omitempty"`
+
+	// READ-ONLY; Subscription ID of single scope recommendations.
+	SubscriptionID string `json:"subscriptionId,omitempty"`
+
+	// READ-ONLY; Resource group of single scope recommendations.
+	ResourceGroup string `json:"resourceGroup,omitempty"`
+}
+
+// AllSavingsBenefitDetails - Benefit recommendation details.
+type AllSavingsBenefitDetails struct {
+	// READ-ONLY; The difference between total cost and benefit cost for the 'totalHours' in the look-back period.
+	SavingsAmount float64 `json:"savingsAmount,omitempty"`
+
+	// READ-ONLY; The savings in percentage for the 'totalHours' in the look-back period.
+	SavingsPercentage float64 `json:"savingsPercentage,omitempty"`
+
+	// READ-ONLY; The commitment amount of the proposed benefit per commitment granularity.
+	CommitmentAmount float64 `json:"commitmentAmount,omitempty"`
+
+	// READ-ONLY; The estimated average utilization percentage for the 'totalHours' in the look-back period, with this commitment.
+	AverageUtilizationPercentage float64 `json:"averageUtilizationPercentage,omitempty"`
+
+	// READ-ONLY; The estimated cost with benefit for the 'totalHours' in the look-back period.
+	BenefitCost float64 `json:"benefitCost,omitempty"`
+
+	// READ-ONLY; Estimated benefit coverage percentage for the 'totalHours' in the look-back period, with this commitment.
+	CoveragePercentage float64 `json:"coveragePercentage,omitempty"`
+
+	// READ-ONLY; The difference between the covered cost and the commitment for the 'totalHours' in the look-back period.
+	OverageCost float64 `json:"overageCost,omitempty"`
+
+	// READ-ONLY; The estimated total cost for the 'totalHours' in the look-back period, with this commitment.
+	TotalCost float64 `json:"totalCost,omitempty"`
+
+	// READ-ONLY; The estimated unused portion of the commitment for the 'totalHours' in the look-back period.
+	WastageCost float64 `json:"wastageCost,omitempty"`
+}
+
+// BenefitRecommendationsListResult - Result of listing benefit recommendations.
+type BenefitRecommendationsListResult struct {
+	// READ-ONLY; The link (url) to the next page of results.
+	NextLink string `json:"nextLink,omitempty"`
+
+	// READ-ONLY; The list of benefit recommendations.
+	Value []BenefitRecommendationModel `json:"value,omitempty"`
+}
+
+// List the benefit recommendations for the scope, following nextLink until
+// every recommendation has been read.
+//   - scope - The scope associated with benefit recommendation operations, e.g. 'subscriptions/{subscriptionId}' or
+//     'providers/Microsoft.Billing/billingAccounts/{billingAccountId}/billingProfiles/{billingProfileId}'.
+//   - filter - Filters the recommendations by properties/scope, properties/lookBackPeriod and properties/term, e.g.
+//     "properties/lookBackPeriod eq 'Last7Days' AND properties/term eq 'P1Y'". Pass "" for the defaults.
+func (client *BenefitRecommendationsClient) List(ctx context.Context, scope string, filter string) ([]BenefitRecommendationModel, error) {
+	urlPath := "/{scope}/providers/Microsoft.CostManagement/benefitRecommendations"
+	urlPath = strings.ReplaceAll(urlPath, "{scope}", strings.Trim(scope, "/"))
+	req, err := client.newListRequest(ctx, runtime.JoinPaths(client.internal.Endpoint(), urlPath))
+	if err != nil {
+		return nil, err
+	}
+	if filter != "" {
+		reqQP := req.Raw().URL.Query()
+		reqQP.Set("$filter", filter)
+		req.Raw().URL.RawQuery = reqQP.Encode()
+	}
+
+	recommendations := make([]BenefitRecommendationModel, 0)
+	for req != nil {
+		resp, err := client.internal.Pipeline().Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if !runtime.HasStatusCode(resp, http.StatusOK) {
+			return nil, newResponseError(resp)
+		}
+
+		var page BenefitRecommendationsListResult
+		if err := runtime.UnmarshalAsJSON(resp, &page); err != nil {
+			return nil, err
+		}
+		recommendations = append(recommendations, page.Value...)
+
+		req = nil
+		if page.NextLink != "" {
+			// The next link carries the filter of the first request
+			if req, err = client.newListRequest(ctx, page.NextLink); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return recommendations, nil
+}
+
+func (client *BenefitRecommendationsClient) newListRequest(ctx context.Context, endpoint string) (*policy.Request, error) {
+	req, err := runtime.NewRequest(ctx, http.MethodGet, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	reqQP := req.Raw().URL.Query()
+	reqQP.Set("api-version", "2023-03-01")
+	req.Raw().URL.RawQuery = reqQP.Encode()
+	req.Raw().Header["Accept"] = []string{"application/json"}
+	return req, nil
+}
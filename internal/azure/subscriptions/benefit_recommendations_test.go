@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package subscriptions
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+)
+
+func TestBenefitRecommendationsClient_List(t *testing.T) {
+	const filter = "properties/lookBackPeriod eq 'Last7Days' AND properties/term eq 'P1Y'"
+
+	var serverURL string
+	options := newTestClientOptions(t, func(w http.ResponseWriter, r *http.Request) {
+		if want := "/providers/Microsoft.Billing/billingAccounts/123/providers/Microsoft.CostManagement/benefitRecommendations"; r.Method != http.MethodGet || r.URL.Path != want {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.URL.Query().Get("$filter"); got != filter {
+			t.Errorf("expected filter %q, got %q", filter, got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("$skiptoken") == "" {
+			_, _ = fmt.Fprintf(w, `{"nextLink": "%s%s?$filter=%s&$skiptoken=1", "value": [{"name": "shared", "kind": "SavingsPlan", "properties": {
+  "scope": "Shared", "term": "P1Y", "lookBackPeriod": "Last7Days", "commitmentGranularity": "Hourly", "currencyCode": "USD",
+  "firstConsumptionDate": "2030-01-01T00:00:00Z", "totalHours": 168,
+  "recommendationDetails": {"commitmentAmount": 2.5, "savingsAmount": 120.5, "savingsPercentage": 21.3}
+}}]}`, serverURL, r.URL.Path, r.URL.Query().Get("$filter"))
+			return
+		}
+		_, _ = w.Write([]byte(`{"value": [{"name": "single", "kind": "SavingsPlan", "properties": {"scope": "Single", "subscriptionId": "00000000-0000-0000-0000-000000000000"}}]}`))
+	})
+	serverURL = options.Cloud.Services[cloud.ResourceManager].Endpoint
+
+	client, err := NewBenefitRecommendationsClient(staticCredential{}, options)
+	if err != nil {
+		t.Fatalf("creating benefit recommendations client: %s", err)
+	}
+
+	got, err := client.List(context.Background(), "/providers/Microsoft.Billing/billingAccounts/123", filter)
+	if err != nil {
+		t.Fatalf("listing benefit recommendations: %s", err)
+	}
+	if len(got) != 2 || got[0].Kind != BenefitKindSavingsPlan || got[0].Properties.Term != TermP1Y || got[0].Properties.FirstConsumptionDate == nil {
+		t.Fatalf("unexpected recommendations: %+v", got)
+	}
+	if details := got[0].Properties.RecommendationDetails; details == nil || details.CommitmentAmount != 2.5 || details.SavingsAmount != 120.5 {
+		t.Fatalf("unexpected recommendation details: %+v", details)
+	}
+	if got[1].Properties.Scope != ScopeSingle || got[1].Properties.SubscriptionID != "00000000-0000-0000-0000-000000000000" {
+		t.Fatalf("unexpected single scope recommendation: %+v", got[1])
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package subscriptions
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+// CostDetailsClient contains the methods for the GenerateCostDetailsReport group.
+// Don't use this type directly, use NewCostDetailsClient() instead.
+type CostDetailsClient struct {
+	internal *arm.Client
+}
+
+// NewCostDetailsClient creates a new instance of CostDetailsClient with the specified values.
+//   - credential - used to authorize requests. Usually a credential from azidentity.
+//   - options - pass nil to accept the default values.
+func NewCostDetailsClient(credential azcore.TokenCredential, options *arm.ClientOptions) (*CostDetailsClient, error) {
+	cl, err := arm.NewClient(moduleName+".CostDetailsClient", moduleVersion, credential, options)
+	if err != nil {
+		return nil, err
+	}
+	client := &CostDetailsClient{
+		internal: cl,
+	}
+	return client, nil
+}
+
+// GenerateCostDetailsReportRequestDefinition - The definition of a cost detailed report. Only one of timePeriod, invoiceId
+// or billingPeriod may be set.
+type GenerateCostDetailsReportRequestDefinition struct {
+	// The type of the detailed report. By default ActualCost is provided
+	Metric CostDetailsMetricType `json:"metric,omitempty"`
+
+	// The specific date range of cost details requested for the report, at most a month.
+	TimePeriod *CostDetailsTimePeriod `json:"timePeriod,omitempty"`
+
+	// Billing period in YearMonth(e.g. 202008) format. Only for legacy Enterprise Agreement customers.
+	BillingPeriod string `json:"billingPeriod,omitempty"`
+
+	// Invoice ID for Pay-as-you-go and Microsoft Customer Agreement scopes.
+	InvoiceID string `json:"invoiceId,omitempty"`
+}
+
+// CostDetailsTimePeriod - The start and end date for pulling data for the cost detailed report.
+type CostDetailsTimePeriod struct {
+	// The start date to pull data from. example format 2020-03-15
+	Start string `json:"start"`
+
+	// The end date to pull data to. example format 2020-03-15
+	End string `json:"end"`
+}
+
+// CostDetailsOperationResults - The result of the long running operation for cost details Api.
+type CostDetailsOperationResults struct {
+	// The id of the long running operation.
+	ID string `json:"id,omitempty"`
+
+	// The name of the long running operation.
+	Name string `json:"name,omitempty"`
+
+	// The type of the long running operation.
+	Type string `json:"type,omitempty"`
+
+	// The status of the cost details operation
+	Status CostDetailsStatusType `json:"status,omitempty"`
+
+	// The details of the error.
+	Error *ErrorDetail `json:"error,omitempty"`
+
+	// The CostDetails Manifest for the report.
+	Manifest *ReportManifest `json:"manifest,omitempty"`
+
+	// The time at which report URL becomes invalid/expires in UTC e.g. 2020-12-08T05:55:59.4394737Z.
+	ValidTill *time.Time `json:"validTill,omitempty"`
+
+	// Properties carries the manifest in the documented shape of the API,
+	// Generate moves it up when the service nests it.
+	Properties *CostDetailsOperationResultsProperties `json:"properties,omitempty"`
+}
+
+// CostDetailsOperationResultsProperties - The properties of the long running operation for cost details Api.
+type CostDetailsOperationResultsProperties struct {
+	// The CostDetails Manifest for the report.
+	Manifest *ReportManifest `json:"manifest,omitempty"`
+
+	// The time at which report URL becomes invalid/expires in UTC e.g. 2020-12-08T05:55:59.4394737Z.
+	ValidTill *time.Time `json:"validTill,omitempty"`
+}
+
+// ReportManifest - The manifest of the report generated by the operation.
+type ReportManifest struct {
+	// The Manifest version.
+	ManifestVersion string `json:"manifestVersion,omitempty"`
+
+	// The data format of the report
+	DataFormat CostDetailsDataFormat `json:"dataFormat,omitempty"`
+
+	// The total number of blobs.
+	BlobCount int32 `json:"blobCount,omitempty"`
+
+	// The total number of bytes in all blobs.
+	ByteCount int64 `json:"byteCount,omitempty"`
+
+	// Is the data in compressed format.
+	CompressData bool `json:"compressData,omitempty"`
+
+	// List of blob information generated by this operation.
+	Blobs []BlobInfo `json:"blobs,omitempty"`
+}
+
+// BlobInfo - The blob information generated by this operation.
+type BlobInfo struct {
+	// Link to the blob to download file.
+	BlobLink string `json:"blobLink,omitempty"`
+
+	// Bytes in the blob.
+	ByteCount int64 `json:"byteCount,omitempty"`
+}
+
+// Generate requests a cost details report for the scope and waits for it to
+// be generated, returning the manifest of the report blobs. A report without
+// cost data has status NoDataFound and no manifest; a failed report is
+// returned as an error.
+//   - scope - The scope associated with usage details operations, e.g. 'subscriptions/{subscriptionId}'.
+//   - parameters - The report to generate.
+func (client *CostDetailsClient) Generate(ctx context.Context, scope string, parameters GenerateCostDetailsReportRequestDefinition) (CostDetailsOperationResults, error) {
+	urlPath := "/{scope}/providers/Microsoft.CostManagement/generateCostDetailsReport"
+	urlPath = strings.ReplaceAll(urlPath, "{scope}", strings.Trim(scope, "/"))
+	req, err := runtime.NewRequest(ctx, http.MethodPost, runtime.JoinPaths(client.internal.Endpoint(), urlPath))
+	if err != nil {
+		return CostDetailsOperationResults{}, err
+	}
+	reqQP := req.Raw().URL.Query()
+	reqQP.Set("api-version", "2023-03-01")
+	req.Raw().URL.RawQuery = reqQP.Encode()
+	req.Raw().Header["Accept"] = []string{"application/json"}
+	if err := runtime.MarshalAsJSON(req, parameters); err != nil {
+		return CostDetailsOperationResults{}, err
+	}
+
+	resp, err := pollOperation(ctx, client.internal.Pipeline(), req)
+	if err != nil {
+		return CostDetailsOperationResults{}, err
+	}
+
+	if resp.StatusCode == http.StatusNoContent {
+		return CostDetailsOperationResults{Status: CostDetailsStatusTypeNoDataFoundCostDetailsStatusType}, nil
+	}
+	if !runtime.HasStatusCode(resp, http.StatusOK) {
+		return CostDetailsOperationResults{}, newResponseError(resp)
+	}
+
+	var result CostDetailsOperationResults
+	if err := runtime.UnmarshalAsJSON(resp, &result); err != nil {
+		return CostDetailsOperationResults{}, err
+	}
+	if result.Manifest == nil && result.Properties != nil {
+		result.Manifest, result.ValidTill = result.Properties.Manifest, result.Properties.ValidTill
+	}
+	result.Properties = nil
+
+	if result.Status == CostDetailsStatusTypeFailedCostDetailsStatusType {
+		if result.Error != nil && result.Error.Message != "" {
+			return CostDetailsOperationResults{}, fmt.Errorf("generating cost details report %s failed: %s: %s", result.Name, result.Error.Code, result.Error.Message)
+		}
+		return CostDetailsOperationResults{}, fmt.Errorf("generating cost details report %s failed", result.Name)
+	}
+	return result, nil
+}
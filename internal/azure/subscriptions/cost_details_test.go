@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package subscriptions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+)
+
+func TestCostDetailsClient_Generate(t *testing.T) {
+	const operationPath = "/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.CostManagement/costDetailsOperationResults/00000000-0000-0000-0000-000000000001"
+
+	var serverURL string
+	polls := 0
+	options := newTestClientOptions(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.CostManagement/generateCostDetailsReport":
+			var report GenerateCostDetailsReportRequestDefinition
+			if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+				t.Fatalf("decoding request: %s", err)
+			}
+			if report.Metric != CostDetailsMetricTypeAmortizedCostCostDetailsMetricType || report.TimePeriod == nil || report.TimePeriod.Start != "2030-01-01" {
+				t.Errorf("unexpected report: %+v", report)
+			}
+			w.Header().Set("Location", fmt.Sprintf("%s%s?api-version=2023-03-01", serverURL, operationPath))
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusAccepted)
+		case r.Method == http.MethodGet && r.URL.Path == operationPath:
+			polls++
+			if polls == 1 {
+				w.Header().Set("Location", fmt.Sprintf("%s%s?api-version=2023-03-01", serverURL, operationPath))
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusAccepted)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{
+  "id": "subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.CostManagement/costDetailsOperationResults/00000000-0000-0000-0000-000000000001",
+  "name": "00000000-0000-0000-0000-000000000001",
+  "status": "Completed",
+  "properties": {
+    "validTill": "2030-01-02T00:00:00Z",
+    "manifest": {
+      "manifestVersion": "2022-05-01",
+      "dataFormat": "Csv",
+      "blobCount": 2,
+      "byteCount": 300,
+      "blobs": [
+        {"blobLink": "https://ccmreportstorage.blob.core.windows.net/part0.csv", "byteCount": 200},
+        {"blobLink": "https://ccmreportstorage.blob.core.windows.net/part1.csv", "byteCount": 100}
+      ]
+    }
+  }
+}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	serverURL = options.Cloud.Services[cloud.ResourceManager].Endpoint
+
+	client, err := NewCostDetailsClient(staticCredential{}, options)
+	if err != nil {
+		t.Fatalf("creating cost details client: %s", err)
+	}
+
+	got, err := client.Generate(context.Background(), "/subscriptions/00000000-0000-0000-0000-000000000000", GenerateCostDetailsReportRequestDefinition{
+		Metric:     CostDetailsMetricTypeAmortizedCostCostDetailsMetricType,
+		TimePeriod: &CostDetailsTimePeriod{Start: "2030-01-01", End: "2030-01-31"},
+	})
+	if err != nil {
+		t.Fatalf("generating report: %s", err)
+	}
+	if polls != 2 || got.Status != CostDetailsStatusTypeCompletedCostDetailsStatusType || got.Manifest == nil || got.ValidTill == nil {
+		t.Fatalf("unexpected result after %d polls: %+v", polls, got)
+	}
+	if len(got.Manifest.Blobs) != 2 || got.Manifest.Blobs[1].BlobLink != "https://ccmreportstorage.blob.core.windows.net/part1.csv" {
+		t.Fatalf("unexpected manifest: %+v", got.Manifest)
+	}
+}
+
+func TestCostDetailsClient_GenerateFailed(t *testing.T) {
+	client, err := NewCostDetailsClient(staticCredential{}, newTestClientOptions(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name": "report", "status": "Failed", "error": {"code": "800", "message": "Report generation failed."}}`))
+	}))
+	if err != nil {
+		t.Fatalf("creating cost details client: %s", err)
+	}
+
+	_, err = client.Generate(context.Background(), "/subscriptions/00000000-0000-0000-0000-000000000000", GenerateCostDetailsReportRequestDefinition{})
+	if err == nil || err.Error() != "generating cost details report report failed: 800: Report generation failed." {
+		t.Fatalf("expected the operation error, got %v", err)
+	}
+}
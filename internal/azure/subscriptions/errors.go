@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package subscriptions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+// ErrorDetail - The error detail.
+type ErrorDetail struct {
+	// The error code.
+	Code string `json:"code,omitempty"`
+
+	// The error message.
+	Message string `json:"message,omitempty"`
+
+	// The error target.
+	Target string `json:"target,omitempty"`
+
+	// The error details.
+	Details []ErrorDetail `json:"details,omitempty"`
+}
+
+// ErrorResponse - Error response indicates that the service is not able to process the incoming request. The reason is provided
+// in the error message.
+type ErrorResponse struct {
+	// The details of the error.
+	Error *ErrorDetail `json:"error,omitempty"`
+}
+
+// ResponseError is returned when the service responds with an unexpected
+// status code. It wraps the *azcore.ResponseError built by the SDK, so
+// errors.As works with either type, and adds the decoded ARM error envelope.
+type ResponseError struct {
+	*azcore.ResponseError
+
+	// Detail is the decoded error envelope, empty when the body could not be
+	// decoded.
+	Detail ErrorDetail
+}
+
+func (e *ResponseError) Error() string {
+	if e.Detail.Message == "" {
+		return e.ResponseError.Error()
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s %s: %d %s: %s",
+		e.RawResponse.Request.Method, e.RawResponse.Request.URL.Path, e.StatusCode, e.Detail.Code, e.Detail.Message)
+	if e.Detail.Target != "" {
+		fmt.Fprintf(&sb, " (target: %s)", e.Detail.Target)
+	}
+	for _, detail := range e.Detail.Details {
+		fmt.Fprintf(&sb, "\n  %s: %s", detail.Code, detail.Message)
+	}
+	return sb.String()
+}
+
+func (e *ResponseError) Unwrap() error {
+	return e.ResponseError
+}
+
+// IsNotFound reports whether err is a ResponseError for a 404 response.
+func IsNotFound(err error) bool {
+	var respErr *azcore.ResponseError
+	return errors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound
+}
+
+// newResponseError builds a ResponseError from resp, decoding the ARM error
+// envelope when the body contains one.
+func newResponseError(resp *http.Response) error {
+	azErr := runtime.NewResponseError(resp)
+
+	var respErr *azcore.ResponseError
+	if !errors.As(azErr, &respErr) {
+		return azErr
+	}
+
+	result := &ResponseError{ResponseError: respErr}
+
+	body, err := runtime.Payload(resp)
+	if err != nil || len(body) == 0 {
+		return result
+	}
+
+	var envelope ErrorResponse
+	if err := json.Unmarshal(body, &envelope); err == nil && envelope.Error != nil {
+		result.Detail = *envelope.Error
+		if result.ErrorCode == "" {
+			result.ErrorCode = envelope.Error.Code
+		}
+	}
+	return result
+}
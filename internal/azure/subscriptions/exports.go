@@ -0,0 +1,312 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package subscriptions
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+// ExportsClient contains the methods for the Exports group.
+// Don't use this type directly, use NewExportsClient() instead.
+type ExportsClient struct {
+	internal *arm.Client
+}
+
+// NewExportsClient creates a new instance of ExportsClient with the specified values.
+//   - credential - used to authorize requests. Usually a credential from azidentity.
+//   - options - pass nil to accept the default values.
+func NewExportsClient(credential azcore.TokenCredential, options *arm.ClientOptions) (*ExportsClient, error) {
+	cl, err := arm.NewClient(moduleName+".ExportsClient", moduleVersion, credential, options)
+	if err != nil {
+		return nil, err
+	}
+	client := &ExportsClient{
+		internal: cl,
+	}
+	return client, nil
+}
+
+// Export - An export resource.
+type Export struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+	Type string `json:"type,omitempty"`
+
+	// eTag of the resource. To handle concurrent update scenario, this field will be used to determine whether the user is
+	// updating the latest version or not.
+	ETag string `json:"eTag,omitempty"`
+
+	Properties ExportProperties `json:"properties"`
+}
+
+// ExportProperties - The properties of the export.
+type ExportProperties struct {
+	// Has the definition for the export.
+	Definition ExportDefinition `json:"definition"`
+
+	// Has delivery information for the export.
+	DeliveryInfo ExportDeliveryInfo `json:"deliveryInfo"`
+
+	// The format of the export being delivered. Currently only 'Csv' is supported.
+	Format FormatType `json:"format,omitempty"`
+
+	// If set to true, exported data will be partitioned by size and placed in a blob directory together with a manifest file.
+	PartitionData bool `json:"partitionData,omitempty"`
+
+	// Has schedule information for the export.
+	Schedule *ExportSchedule `json:"schedule,omitempty"`
+
+	// READ-ONLY; If the export has an active schedule, provides an estimate of the next run time.
+	NextRunTimeEstimate *time.Time `json:"nextRunTimeEstimate,omitempty"`
+
+	// If requested, has the most recent run history for the export.
+	RunHistory *ExportExecutionListResult `json:"runHistory,omitempty"`
+}
+
+// ExportDefinition - The definition of an export.
+type ExportDefinition struct {
+	// The time frame for pulling data for the export. If custom, then a specific time period must be provided.
+	Timeframe TimeframeType `json:"timeframe"`
+
+	// The type of the export. Note that 'Usage' is equivalent to 'ActualCost' and is applicable to exports that do not yet
+	// provide data for charges or amortization for service reservations.
+	Type ExportType `json:"type"`
+
+	// The definition for data in the export.
+	DataSet *ExportDataset `json:"dataSet,omitempty"`
+
+	// Has time period for pulling data for the export.
+	TimePeriod *ExportTimePeriod `json:"timePeriod,omitempty"`
+}
+
+// ExportDataset - The definition for data in the export.
+type ExportDataset struct {
+	// The export dataset configuration.
+	Configuration *ExportDatasetConfiguration `json:"configuration,omitempty"`
+
+	// The granularity of rows in the export. Currently only 'Daily' is supported.
+	Granularity GranularityType `json:"granularity,omitempty"`
+}
+
+// ExportDatasetConfiguration - The export dataset configuration. Allows columns to be selected for the export. If not provided
+// then the export will include all available columns.
+type ExportDatasetConfiguration struct {
+	// Array of column names to be included in the export.
+	Columns []string `json:"columns,omitempty"`
+}
+
+// ExportTimePeriod - The date range for data in the export. This should only be specified with timeFrame set to 'Custom'.
+// The maximum date range is 3 months.
+type ExportTimePeriod struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// ExportDeliveryInfo - The delivery information associated with a export.
+type ExportDeliveryInfo struct {
+	// Has destination for the export being delivered.
+	Destination ExportDeliveryDestination `json:"destination"`
+}
+
+// ExportDeliveryDestination - The destination information for the delivery of the export. To allow access to a storage
+// account, you must register the account's subscription with the Microsoft.CostManagementExports resource provider.
+type ExportDeliveryDestination struct {
+	// The name of the container where exports will be uploaded. If the container does not exist it will be created.
+	Container string `json:"container"`
+
+	// The resource id of the storage account where exports will be delivered.
+	ResourceID string `json:"resourceId,omitempty"`
+
+	// The name of the directory where exports will be uploaded.
+	RootFolderPath string `json:"rootFolderPath,omitempty"`
+}
+
+// ExportSchedule - The schedule associated with the export.
+type ExportSchedule struct {
+	// The schedule recurrence.
+	Recurrence RecurrenceType `json:"recurrence,omitempty"`
+
+	// Has start and end date of the recurrence. The start date must be in future. If present, the end date must be greater
+	// than start date.
+	RecurrencePeriod *ExportRecurrencePeriod `json:"recurrencePeriod,omitempty"`
+
+	// The status of the export's schedule. If 'Inactive', the export's schedule is paused.
+	Status StatusType `json:"status,omitempty"`
+}
+
+// ExportRecurrencePeriod - The start and end date for recurrence schedule.
+type ExportRecurrencePeriod struct {
+	From time.Time  `json:"from"`
+	To   *time.Time `json:"to,omitempty"`
+}
+
+// ExportExecutionListResult - Result of listing the run history of an export.
+type ExportExecutionListResult struct {
+	// READ-ONLY; A list of export runs.
+	Value []ExportRun `json:"value"`
+}
+
+// ExportRun - An export run.
+type ExportRun struct {
+	ID         string              `json:"id,omitempty"`
+	Name       string              `json:"name,omitempty"`
+	Properties ExportRunProperties `json:"properties"`
+}
+
+// ExportRunProperties - The properties of the export run.
+type ExportRunProperties struct {
+	// The details of any error.
+	Error *ErrorDetail `json:"error,omitempty"`
+
+	// The type of the export run.
+	ExecutionType ExecutionType `json:"executionType,omitempty"`
+
+	// The name of the exported file.
+	FileName string `json:"fileName,omitempty"`
+
+	// The time when the export run finished.
+	ProcessingEndTime *time.Time `json:"processingEndTime,omitempty"`
+
+	// The time when export was picked up to be run.
+	ProcessingStartTime *time.Time `json:"processingStartTime,omitempty"`
+
+	// The last known status of the export run.
+	Status ExecutionStatus `json:"status,omitempty"`
+
+	// The identifier for the entity that triggered the export. For on-demand runs it is the user email. For scheduled runs
+	// it is 'System'.
+	SubmittedBy string `json:"submittedBy,omitempty"`
+
+	// The time when export was queued to be run.
+	SubmittedTime *time.Time `json:"submittedTime,omitempty"`
+}
+
+// Get the export for the defined scope by export name. Set expandRunHistory
+// to include the most recent runs in Properties.RunHistory.
+//   - scope - The scope associated with export operations, e.g. 'subscriptions/{subscriptionId}'.
+//   - exportName - Export Name.
+func (client *ExportsClient) Get(ctx context.Context, scope string, exportName string, expandRunHistory bool) (Export, error) {
+	req, err := client.newRequest(ctx, http.MethodGet, scope, exportName, "")
+	if err != nil {
+		return Export{}, err
+	}
+	if expandRunHistory {
+		reqQP := req.Raw().URL.Query()
+		reqQP.Set("$expand", "runHistory")
+		req.Raw().URL.RawQuery = reqQP.Encode()
+	}
+
+	resp, err := client.internal.Pipeline().Do(req)
+	if err != nil {
+		return Export{}, err
+	}
+
+	if !runtime.HasStatusCode(resp, http.StatusOK) {
+		return Export{}, newResponseError(resp)
+	}
+
+	var export Export
+	if err := runtime.UnmarshalAsJSON(resp, &export); err != nil {
+		return Export{}, err
+	}
+	return export, nil
+}
+
+// CreateOrUpdate creates or updates the export. Updating requires the latest
+// eTag of the export in parameters.ETag.
+//   - scope - The scope associated with export operations, e.g. 'subscriptions/{subscriptionId}'.
+//   - exportName - Export Name.
+func (client *ExportsClient) CreateOrUpdate(ctx context.Context, scope string, exportName string, parameters Export) (Export, error) {
+	req, err := client.newRequest(ctx, http.MethodPut, scope, exportName, "")
+	if err != nil {
+		return Export{}, err
+	}
+	if err := runtime.MarshalAsJSON(req, parameters); err != nil {
+		return Export{}, err
+	}
+
+	resp, err := client.internal.Pipeline().Do(req)
+	if err != nil {
+		return Export{}, err
+	}
+
+	if !runtime.HasStatusCode(resp, http.StatusOK, http.StatusCreated) {
+		return Export{}, newResponseError(resp)
+	}
+
+	var export Export
+	if err := runtime.UnmarshalAsJSON(resp, &export); err != nil {
+		return Export{}, err
+	}
+	return export, nil
+}
+
+// Delete the export. An export that does not exist is treated as already
+// deleted.
+//   - scope - The scope associated with export operations, e.g. 'subscriptions/{subscriptionId}'.
+//   - exportName - Export Name.
+func (client *ExportsClient) Delete(ctx context.Context, scope string, exportName string) error {
+	req, err := client.newRequest(ctx, http.MethodDelete, scope, exportName, "")
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.internal.Pipeline().Do(req)
+	if err != nil {
+		return err
+	}
+
+	if !runtime.HasStatusCode(resp, http.StatusOK, http.StatusNoContent, http.StatusNotFound) {
+		return newResponseError(resp)
+	}
+	return nil
+}
+
+// Execute runs the export now, in addition to its schedule.
+//   - scope - The scope associated with export operations, e.g. 'subscriptions/{subscriptionId}'.
+//   - exportName - Export Name.
+func (client *ExportsClient) Execute(ctx context.Context, scope string, exportName string) error {
+	req, err := client.newRequest(ctx, http.MethodPost, scope, exportName, "/run")
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.internal.Pipeline().Do(req)
+	if err != nil {
+		return err
+	}
+
+	if !runtime.HasStatusCode(resp, http.StatusOK) {
+		return newResponseError(resp)
+	}
+	return nil
+}
+
+func (client *ExportsClient) newRequest(ctx context.Context, method string, scope string, exportName string, suffix string) (*policy.Request, error) {
+	urlPath := "/{scope}/providers/Microsoft.CostManagement/exports/{exportName}"
+	if exportName == "" {
+		return nil, errors.New("parameter exportName cannot be empty")
+	}
+	urlPath = strings.ReplaceAll(urlPath, "{scope}", strings.Trim(scope, "/"))
+	urlPath = strings.ReplaceAll(urlPath, "{exportName}", url.PathEscape(exportName))
+	req, err := runtime.NewRequest(ctx, method, runtime.JoinPaths(client.internal.Endpoint(), urlPath+suffix))
+	if err != nil {
+		return nil, err
+	}
+	reqQP := req.Raw().URL.Query()
+	reqQP.Set("api-version", "2023-03-01")
+	req.Raw().URL.RawQuery = reqQP.Encode()
+	req.Raw().Header["Accept"] = []string{"application/json"}
+	return req, nil
+}
@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package subscriptions
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestExportsClient_Get(t *testing.T) {
+	client, err := NewExportsClient(staticCredential{}, newTestClientOptions(t, func(w http.ResponseWriter, r *http.Request) {
+		if want := "/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.CostManagement/exports/monthly"; r.URL.Path != want {
+			t.Errorf("expected path %q, got %q", want, r.URL.Path)
+		}
+		if got := r.URL.Query().Get("$expand"); got != "runHistory" {
+			t.Errorf("expected $expand=runHistory, got %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+  "id": "/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.CostManagement/exports/monthly",
+  "name": "monthly",
+  "eTag": "\"1d4ff9fe66f1d10\"",
+  "properties": {
+    "definition": {"type": "ActualCost", "timeframe": "MonthToDate", "dataSet": {"granularity": "Daily"}},
+    "deliveryInfo": {"destination": {"resourceId": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/example", "container": "exports"}},
+    "format": "Csv",
+    "schedule": {"status": "Active", "recurrence": "Daily", "recurrencePeriod": {"from": "2030-01-01T00:00:00Z"}},
+    "runHistory": {"value": [{"properties": {"executionType": "OnDemand", "status": "Completed", "submittedTime": "2030-01-01T10:00:00Z"}}]}
+  }
+}`))
+	}))
+	if err != nil {
+		t.Fatalf("creating exports client: %s", err)
+	}
+
+	got, err := client.Get(context.Background(), "/subscriptions/00000000-0000-0000-0000-000000000000", "monthly", true)
+	if err != nil {
+		t.Fatalf("getting export: %s", err)
+	}
+	if got.Properties.Definition.Type != ExportTypeActualCost || got.Properties.Schedule == nil || got.Properties.Schedule.Recurrence != RecurrenceTypeDaily {
+		t.Fatalf("unexpected export: %+v", got)
+	}
+	if got.Properties.RunHistory == nil || len(got.Properties.RunHistory.Value) != 1 || got.Properties.RunHistory.Value[0].Properties.Status != ExecutionStatusCompleted {
+		t.Fatalf("unexpected run history: %+v", got.Properties.RunHistory)
+	}
+}
+
+func TestExportsClient_Execute(t *testing.T) {
+	client, err := NewExportsClient(staticCredential{}, newTestClientOptions(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.CostManagement/exports/monthly/run" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	if err != nil {
+		t.Fatalf("creating exports client: %s", err)
+	}
+
+	if err := client.Execute(context.Background(), "subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example", "monthly"); err != nil {
+		t.Fatalf("running export: %s", err)
+	}
+}
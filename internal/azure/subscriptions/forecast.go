@@ -0,0 +1,170 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package subscriptions
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+// ForecastClient contains the methods for the Forecast group.
+// Don't use this type directly, use NewForecastClient() instead.
+type ForecastClient struct {
+	internal *arm.Client
+}
+
+// NewForecastClient creates a new instance of ForecastClient with the specified values.
+//   - credential - used to authorize requests. Usually a credential from azidentity.
+//   - options - pass nil to accept the default values.
+func NewForecastClient(credential azcore.TokenCredential, options *arm.ClientOptions) (*ForecastClient, error) {
+	cl, err := arm.NewClient(moduleName+".ForecastClient", moduleVersion, credential, options)
+	if err != nil {
+		return nil, err
+	}
+	client := &ForecastClient{
+		internal: cl,
+	}
+	return client, nil
+}
+
+// ForecastDefinition - The definition of a forecast.
+type ForecastDefinition struct {
+	// Has definition for data in this forecast.
+	Dataset ForecastDataset `json:"dataset"`
+
+	// The time frame for pulling data for the forecast.
+	Timeframe ForecastTimeframe `json:"timeframe"`
+
+	// The type of the forecast.
+	Type ForecastType `json:"type"`
+
+	// A boolean determining if actualCost will be included.
+	IncludeActualCost *bool `json:"includeActualCost,omitempty"`
+
+	// A boolean determining if FreshPartialCost will be included.
+	IncludeFreshPartialCost *bool `json:"includeFreshPartialCost,omitempty"`
+
+	// Has time period for pulling data for the forecast.
+	TimePeriod *QueryTimePeriod `json:"timePeriod,omitempty"`
+}
+
+// ForecastDataset - The definition of data present in the forecast.
+type ForecastDataset struct {
+	// Dictionary of aggregation expression to use in the forecast. The key of each item in the dictionary is the alias for
+	// the aggregated column. forecast can have up to 2 aggregation clauses.
+	Aggregation map[string]ForecastAggregation `json:"aggregation"`
+
+	// Has configuration information for the data in the export. The configuration will be ignored if aggregation and grouping
+	// are provided.
+	Configuration *QueryDatasetConfiguration `json:"configuration,omitempty"`
+
+	// Has filter expression to use in the forecast.
+	Filter *ForecastFilter `json:"filter,omitempty"`
+
+	// The granularity of rows in the forecast.
+	Granularity GranularityType `json:"granularity,omitempty"`
+}
+
+// ForecastAggregation - The aggregation expression to be used in the forecast.
+type ForecastAggregation struct {
+	// The name of the aggregation function to use.
+	Function FunctionType `json:"function"`
+
+	// The name of the column to aggregate.
+	Name FunctionName `json:"name"`
+}
+
+// ForecastFilter - The filter expression to be used in the forecast.
+type ForecastFilter struct {
+	// The logical "AND" expression. Must have at least 2 items.
+	And []ForecastFilter `json:"and,omitempty"`
+
+	// Has comparison expression for a dimension
+	Dimensions *ForecastComparisonExpression `json:"dimensions,omitempty"`
+
+	// The logical "OR" expression. Must have at least 2 items.
+	Or []ForecastFilter `json:"or,omitempty"`
+
+	// Has comparison expression for a tag
+	Tags *ForecastComparisonExpression `json:"tags,omitempty"`
+}
+
+// ForecastComparisonExpression - The comparison expression to be used in the forecast.
+type ForecastComparisonExpression struct {
+	// The name of the column to use in comparison.
+	Name string `json:"name"`
+
+	// The operator to use for comparison.
+	Operator ForecastOperatorType `json:"operator"`
+
+	// Array of values to use for comparison
+	Values []string `json:"values"`
+}
+
+// Usage lists the forecast charges for scope defined, following nextLink
+// until every row has been read. The result has the same shape as a query
+// result, with a CostStatus column telling actual and forecasted costs apart.
+//   - scope - The scope associated with forecast operations, e.g. 'subscriptions/{subscriptionId}'.
+//   - parameters - The forecast to run.
+func (client *ForecastClient) Usage(ctx context.Context, scope string, parameters ForecastDefinition) (QueryResult, error) {
+	urlPath := "/{scope}/providers/Microsoft.CostManagement/forecast"
+	urlPath = strings.ReplaceAll(urlPath, "{scope}", strings.Trim(scope, "/"))
+	req, err := client.newRequest(ctx, runtime.JoinPaths(client.internal.Endpoint(), urlPath), parameters)
+	if err != nil {
+		return QueryResult{}, err
+	}
+
+	var result QueryResult
+	for page := 0; req != nil; page++ {
+		resp, err := client.internal.Pipeline().Do(req)
+		if err != nil {
+			return QueryResult{}, err
+		}
+
+		if !runtime.HasStatusCode(resp, http.StatusOK) {
+			return QueryResult{}, newResponseError(resp)
+		}
+
+		var current QueryResult
+		if err := runtime.UnmarshalAsJSON(resp, &current); err != nil {
+			return QueryResult{}, err
+		}
+		if page == 0 {
+			result = current
+		} else {
+			result.Properties.Rows = append(result.Properties.Rows, current.Properties.Rows...)
+		}
+
+		req = nil
+		if current.Properties.NextLink != "" {
+			// The next page is requested with the same forecast
+			if req, err = client.newRequest(ctx, current.Properties.NextLink, parameters); err != nil {
+				return QueryResult{}, err
+			}
+		}
+	}
+	result.Properties.NextLink = ""
+	return result, nil
+}
+
+func (client *ForecastClient) newRequest(ctx context.Context, endpoint string, parameters ForecastDefinition) (*policy.Request, error) {
+	req, err := runtime.NewRequest(ctx, http.MethodPost, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	reqQP := req.Raw().URL.Query()
+	reqQP.Set("api-version", "2023-03-01")
+	req.Raw().URL.RawQuery = reqQP.Encode()
+	req.Raw().Header["Accept"] = []string{"application/json"}
+	if err := runtime.MarshalAsJSON(req, parameters); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package subscriptions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+)
+
+func TestForecastClient_Usage(t *testing.T) {
+	var serverURL string
+	requests := 0
+	options := newTestClientOptions(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Method != http.MethodPost || r.URL.Path != "/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.CostManagement/forecast" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		var forecast ForecastDefinition
+		if err := json.NewDecoder(r.Body).Decode(&forecast); err != nil {
+			t.Fatalf("decoding request: %s", err)
+		}
+		if forecast.Timeframe != ForecastTimeframeCustom || forecast.IncludeActualCost == nil || !*forecast.IncludeActualCost || forecast.Dataset.Aggregation["totalCost"].Name != FunctionNameCost {
+			t.Errorf("unexpected forecast: %+v", forecast)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("$skiptoken") == "" {
+			_, _ = fmt.Fprintf(w, `{"properties": {"nextLink": "%s%s?$skiptoken=1", "columns": [{"name": "totalCost", "type": "Number"}, {"name": "CostStatus", "type": "String"}, {"name": "Currency", "type": "String"}], "rows": [[16, "Actual", "USD"]]}}`, serverURL, r.URL.Path)
+			return
+		}
+		_, _ = w.Write([]byte(`{"properties": {"columns": [{"name": "totalCost", "type": "Number"}, {"name": "CostStatus", "type": "String"}, {"name": "Currency", "type": "String"}], "rows": [[570, "Forecast", "USD"]]}}`))
+	})
+	serverURL = options.Cloud.Services[cloud.ResourceManager].Endpoint
+
+	client, err := NewForecastClient(staticCredential{}, options)
+	if err != nil {
+		t.Fatalf("creating forecast client: %s", err)
+	}
+
+	includeActualCost := true
+	got, err := client.Usage(context.Background(), "/subscriptions/00000000-0000-0000-0000-000000000000", ForecastDefinition{
+		Type:              ForecastTypeActualCost,
+		Timeframe:         ForecastTimeframeCustom,
+		IncludeActualCost: &includeActualCost,
+		TimePeriod: &QueryTimePeriod{
+			From: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2030, 2, 28, 0, 0, 0, 0, time.UTC),
+		},
+		Dataset: ForecastDataset{
+			Aggregation: map[string]ForecastAggregation{"totalCost": {Name: FunctionNameCost, Function: FunctionTypeSum}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("forecasting usage: %s", err)
+	}
+	if requests != 2 || len(got.Properties.Rows) != 2 || got.Properties.Rows[1][0] != float64(570) || got.Properties.Rows[1][1] != "Forecast" || got.Properties.NextLink != "" {
+		t.Fatalf("unexpected result after %d requests: %+v", requests, got)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package subscriptions
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+// lroPollInterval is how long pollOperation waits between polls when the
+// service does not send Retry-After.
+var lroPollInterval = 10 * time.Second
+
+// pollOperation sends req and, while the service answers 202 Accepted,
+// polls the Location header of the answer, waiting as long as its
+// Retry-After header asks. The first response that is not 202 is returned
+// for the caller to check and decode, the way Cost Management's report
+// generation operations finish.
+func pollOperation(ctx context.Context, pipeline runtime.Pipeline, req *policy.Request) (*http.Response, error) {
+	resp, err := pipeline.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	for resp.StatusCode == http.StatusAccepted {
+		// Only the headers of an accepted response are used, release its body
+		// before polling again or giving up
+		runtime.Drain(resp)
+
+		location := resp.Header.Get("Location")
+		if location == "" {
+			return nil, errors.New("operation was accepted without a Location to poll")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(retryAfter(resp)):
+		}
+
+		req, err = runtime.NewRequest(ctx, http.MethodGet, location)
+		if err != nil {
+			return nil, err
+		}
+		req.Raw().Header["Accept"] = []string{"application/json"}
+
+		if resp, err = pipeline.Do(req); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// retryAfter returns the delay the Retry-After header of resp asks for,
+// either in seconds or as an HTTP date, falling back to lroPollInterval.
+func retryAfter(resp *http.Response) time.Duration {
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return lroPollInterval
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if at, err := http.ParseTime(header); err == nil {
+		return max(time.Until(at), 0)
+	}
+	return lroPollInterval
+}
@@ -0,0 +1,218 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package subscriptions
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+// QueryClient contains the methods for the Query group.
+// Don't use this type directly, use NewQueryClient() instead.
+type QueryClient struct {
+	internal *arm.Client
+}
+
+// NewQueryClient creates a new instance of QueryClient with the specified values.
+//   - credential - used to authorize requests. Usually a credential from azidentity.
+//   - options - pass nil to accept the default values.
+func NewQueryClient(credential azcore.TokenCredential, options *arm.ClientOptions) (*QueryClient, error) {
+	cl, err := arm.NewClient(moduleName+".QueryClient", moduleVersion, credential, options)
+	if err != nil {
+		return nil, err
+	}
+	client := &QueryClient{
+		internal: cl,
+	}
+	return client, nil
+}
+
+// QueryDefinition - The definition of a query.
+type QueryDefinition struct {
+	// The time frame for pulling data for the query. If custom, then a specific time period must be provided.
+	Timeframe TimeframeType `json:"timeframe"`
+
+	// The type of the query.
+	Type ExportType `json:"type"`
+
+	// Has definition for data in this query.
+	Dataset QueryDataset `json:"dataset"`
+
+	// Has time period for pulling data for the query.
+	TimePeriod *QueryTimePeriod `json:"timePeriod,omitempty"`
+}
+
+// QueryDataset - The definition of data present in the query.
+type QueryDataset struct {
+	// Dictionary of aggregation expression to use in the query. The key of each item in the dictionary is the alias for the
+	// aggregated column. Query can have up to 2 aggregation clauses.
+	Aggregation map[string]QueryAggregation `json:"aggregation,omitempty"`
+
+	// Has configuration information for the data in the export. The configuration will be ignored if aggregation and grouping
+	// are provided.
+	Configuration *QueryDatasetConfiguration `json:"configuration,omitempty"`
+
+	// The filter expression to use in the query.
+	Filter *QueryFilter `json:"filter,omitempty"`
+
+	// The granularity of rows in the query.
+	Granularity GranularityType `json:"granularity,omitempty"`
+
+	// Array of group by expression to use in the query. Query can have up to 2 group by clauses.
+	Grouping []QueryGrouping `json:"grouping,omitempty"`
+}
+
+// QueryAggregation - The aggregation expression to be used in the query.
+type QueryAggregation struct {
+	// The name of the aggregation function to use.
+	Function FunctionType `json:"function"`
+
+	// The name of the column to aggregate.
+	Name string `json:"name"`
+}
+
+// QueryDatasetConfiguration - The configuration of dataset in the query.
+type QueryDatasetConfiguration struct {
+	// Array of column names to be included in the query. Any valid query column name is allowed. If not provided, then query
+	// includes all columns.
+	Columns []string `json:"columns,omitempty"`
+}
+
+// QueryFilter - The filter expression to be used in the export.
+type QueryFilter struct {
+	// The logical "AND" expression. Must have at least 2 items.
+	And []QueryFilter `json:"and,omitempty"`
+
+	// Has comparison expression for a dimension
+	Dimensions *QueryComparisonExpression `json:"dimensions,omitempty"`
+
+	// The logical "OR" expression. Must have at least 2 items.
+	Or []QueryFilter `json:"or,omitempty"`
+
+	// Has comparison expression for a tag
+	Tags *QueryComparisonExpression `json:"tags,omitempty"`
+}
+
+// QueryComparisonExpression - The comparison expression to be used in the query.
+type QueryComparisonExpression struct {
+	// The name of the column to use in comparison.
+	Name string `json:"name"`
+
+	// The operator to use for comparison.
+	Operator QueryOperatorType `json:"operator"`
+
+	// Array of values to use for comparison
+	Values []string `json:"values"`
+}
+
+// QueryGrouping - The group by expression to be used in the query.
+type QueryGrouping struct {
+	// The name of the column to group.
+	Name string `json:"name"`
+
+	// Has type of the column to group.
+	Type QueryColumnType `json:"type"`
+}
+
+// QueryTimePeriod - The start and end date for pulling data for the query.
+type QueryTimePeriod struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// QueryResult - Result of query. It contains all columns listed under groupings and aggregation.
+type QueryResult struct {
+	ID         string          `json:"id,omitempty"`
+	Name       string          `json:"name,omitempty"`
+	Type       string          `json:"type,omitempty"`
+	Properties QueryProperties `json:"properties"`
+}
+
+// QueryProperties - Query properties
+type QueryProperties struct {
+	// Array of columns
+	Columns []QueryColumn `json:"columns,omitempty"`
+
+	// The link (url) to the next page of results.
+	NextLink string `json:"nextLink,omitempty"`
+
+	// Array of rows
+	Rows [][]any `json:"rows,omitempty"`
+}
+
+// QueryColumn - A column of a query result.
+type QueryColumn struct {
+	// The name of column.
+	Name string `json:"name"`
+
+	// The type of column, e.g. 'Number', 'String' or 'Datetime'.
+	Type string `json:"type"`
+}
+
+// Usage queries the usage data for scope defined, following nextLink until
+// every row has been read. The columns are those of the first page.
+//   - scope - The scope associated with query and export operations, e.g. 'subscriptions/{subscriptionId}'.
+//   - parameters - The query to run.
+func (client *QueryClient) Usage(ctx context.Context, scope string, parameters QueryDefinition) (QueryResult, error) {
+	urlPath := "/{scope}/providers/Microsoft.CostManagement/query"
+	urlPath = strings.ReplaceAll(urlPath, "{scope}", strings.Trim(scope, "/"))
+	req, err := client.newRequest(ctx, runtime.JoinPaths(client.internal.Endpoint(), urlPath), parameters)
+	if err != nil {
+		return QueryResult{}, err
+	}
+
+	var result QueryResult
+	for page := 0; req != nil; page++ {
+		resp, err := client.internal.Pipeline().Do(req)
+		if err != nil {
+			return QueryResult{}, err
+		}
+
+		if !runtime.HasStatusCode(resp, http.StatusOK) {
+			return QueryResult{}, newResponseError(resp)
+		}
+
+		var current QueryResult
+		if err := runtime.UnmarshalAsJSON(resp, &current); err != nil {
+			return QueryResult{}, err
+		}
+		if page == 0 {
+			result = current
+		} else {
+			result.Properties.Rows = append(result.Properties.Rows, current.Properties.Rows...)
+		}
+
+		req = nil
+		if current.Properties.NextLink != "" {
+			// The next page is requested with the same query
+			if req, err = client.newRequest(ctx, current.Properties.NextLink, parameters); err != nil {
+				return QueryResult{}, err
+			}
+		}
+	}
+	result.Properties.NextLink = ""
+	return result, nil
+}
+
+func (client *QueryClient) newRequest(ctx context.Context, endpoint string, parameters QueryDefinition) (*policy.Request, error) {
+	req, err := runtime.NewRequest(ctx, http.MethodPost, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	reqQP := req.Raw().URL.Query()
+	reqQP.Set("api-version", "2023-03-01")
+	req.Raw().URL.RawQuery = reqQP.Encode()
+	req.Raw().Header["Accept"] = []string{"application/json"}
+	if err := runtime.MarshalAsJSON(req, parameters); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package subscriptions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+)
+
+func TestQueryClient_Usage(t *testing.T) {
+	var serverURL string
+	requests := 0
+	options := newTestClientOptions(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Method != http.MethodPost || r.URL.Path != "/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.CostManagement/query" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		var query QueryDefinition
+		if err := json.NewDecoder(r.Body).Decode(&query); err != nil {
+			t.Fatalf("decoding request: %s", err)
+		}
+		if query.Dataset.Aggregation["totalCost"].Function != FunctionTypeSum || query.Dataset.Filter == nil || query.Dataset.Filter.Dimensions.Operator != QueryOperatorTypeIn {
+			t.Errorf("unexpected query: %+v", query)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("$skiptoken") == "" {
+			_, _ = fmt.Fprintf(w, `{"properties": {"nextLink": "%s%s?$skiptoken=1", "columns": [{"name": "totalCost", "type": "Number"}, {"name": "Currency", "type": "String"}], "rows": [[1.5, "USD"]]}}`, serverURL, r.URL.Path)
+			return
+		}
+		_, _ = w.Write([]byte(`{"properties": {"columns": [{"name": "totalCost", "type": "Number"}, {"name": "Currency", "type": "String"}], "rows": [[2.5, "USD"]]}}`))
+	})
+	serverURL = options.Cloud.Services[cloud.ResourceManager].Endpoint
+
+	client, err := NewQueryClient(staticCredential{}, options)
+	if err != nil {
+		t.Fatalf("creating query client: %s", err)
+	}
+
+	got, err := client.Usage(context.Background(), "/subscriptions/00000000-0000-0000-0000-000000000000", QueryDefinition{
+		Type:      ExportTypeActualCost,
+		Timeframe: TimeframeTypeMonthToDate,
+		Dataset: QueryDataset{
+			Aggregation: map[string]QueryAggregation{"totalCost": {Name: "Cost", Function: FunctionTypeSum}},
+			Filter:      &QueryFilter{Dimensions: &QueryComparisonExpression{Name: "ResourceGroupName", Operator: QueryOperatorTypeIn, Values: []string{"app"}}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("querying usage: %s", err)
+	}
+	if requests != 2 || len(got.Properties.Columns) != 2 || len(got.Properties.Rows) != 2 || got.Properties.Rows[1][0] != 2.5 || got.Properties.NextLink != "" {
+		t.Fatalf("unexpected result after %d requests: %+v", requests, got)
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package subscriptions
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+// ScheduledActionsClient contains the methods for the ScheduledActions group.
+// Don't use this type directly, use NewScheduledActionsClient() instead.
+type ScheduledActionsClient struct {
+	internal *arm.Client
+}
+
+// NewScheduledActionsClient creates a new instance of ScheduledActionsClient with the specified values.
+//   - credential - used to authorize requests. Usually a credential from azidentity.
+//   - options - pass nil to accept the default values.
+func NewScheduledActionsClient(credential azcore.TokenCredential, options *arm.ClientOptions) (*ScheduledActionsClient, error) {
+	cl, err := arm.NewClient(moduleName+".ScheduledActionsClient", moduleVersion, credential, options)
+	if err != nil {
+		return nil, err
+	}
+	client := &ScheduledActionsClient{
+		internal: cl,
+	}
+	return client, nil
+}
+
+// ScheduledAction - Scheduled action definition.
+type ScheduledAction struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+	Type string `json:"type,omitempty"`
+
+	// Resource Etag. For update calls, eTag is optional and can be specified to achieve optimistic concurrency. Fetch the resource's
+	// eTag by doing a 'GET' call first and then including the latest eTag as part of the request body or 'If-Match' header
+	// while performing the update.
+	ETag string `json:"eTag,omitempty"`
+
+	// Kind of the scheduled action.
+	Kind ScheduledActionKind `json:"kind,omitempty"`
+
+	// The properties of the scheduled action.
+	Properties ScheduledActionProperties `json:"properties"`
+}
+
+// ScheduledActionProperties - The properties of the scheduled action.
+type ScheduledActionProperties struct {
+	// Scheduled action name.
+	DisplayName string `json:"displayName"`
+
+	// Notification properties based on scheduled action kind.
+	Notification NotificationProperties `json:"notification"`
+
+	// Schedule of the scheduled action.
+	Schedule ScheduleProperties `json:"schedule"`
+
+	// Status of the scheduled action.
+	Status ScheduledActionStatus `json:"status"`
+
+	// Cost analysis viewId used for scheduled action. For example, '/providers/Microsoft.CostManagement/views/swaggerExample'
+	ViewID string `json:"viewId"`
+
+	// Destination format of the view data. This is optional.
+	FileDestination *FileDestination `json:"fileDestination,omitempty"`
+
+	// Email address of the point of contact that should get the unsubscribe requests and notification emails.
+	NotificationEmail string `json:"notificationEmail,omitempty"`
+
+	// Cost Management scope like 'subscriptions/{subscriptionId}' for subscription scope.
+	Scope string `json:"scope,omitempty"`
+}
+
+// NotificationProperties - The properties of the scheduled action notification.
+type NotificationProperties struct {
+	// Subject of the email. Length is limited to 70 characters.
+	Subject string `json:"subject"`
+
+	// Array of email addresses.
+	To []string `json:"to"`
+
+	// Locale of the email.
+	Language string `json:"language,omitempty"`
+
+	// Optional message to be added in the email. Length is limited to 250 characters.
+	Message string `json:"message,omitempty"`
+
+	// Regional format used for formatting date/time and currency values in the email.
+	RegionalFormat string `json:"regionalFormat,omitempty"`
+}
+
+// ScheduleProperties - The properties of the schedule.
+type ScheduleProperties struct {
+	// The end date and time of the scheduled action (UTC).
+	EndDate time.Time `json:"endDate"`
+
+	// Frequency of the schedule.
+	Frequency ScheduleFrequency `json:"frequency"`
+
+	// The start date and time of the scheduled action (UTC).
+	StartDate time.Time `json:"startDate"`
+
+	// UTC day on which cost analysis data will be emailed. Must be between 1 and 31. This property is applicable when frequency
+	// is Monthly and overrides weeksOfMonth or daysOfWeek.
+	DayOfMonth *int32 `json:"dayOfMonth,omitempty"`
+
+	// Day names in english on which cost analysis data will be emailed. This property is applicable when frequency is Weekly
+	// or Monthly.
+	DaysOfWeek []DaysOfWeek `json:"daysOfWeek,omitempty"`
+
+	// UTC time at which cost analysis data will be emailed.
+	HourOfDay *int32 `json:"hourOfDay,omitempty"`
+
+	// Weeks in which cost analysis data will be emailed. This property is applicable when frequency is Monthly and used in
+	// combination with daysOfWeek.
+	WeeksOfMonth []WeeksOfMonth `json:"weeksOfMonth,omitempty"`
+}
+
+// FileDestination - Destination of the view data. This is optional. Currently only CSV format is supported.
+type FileDestination struct {
+	// Destination of the view data. Currently only CSV format is supported.
+	FileFormats []FileFormat `json:"fileFormats,omitempty"`
+}
+
+// Get the scheduled action at scope by name.
+//   - scope - The scope associated with scheduled action operations, e.g. 'subscriptions/{subscriptionId}'.
+//   - name - Scheduled action name.
+func (client *ScheduledActionsClient) Get(ctx context.Context, scope string, name string) (ScheduledAction, error) {
+	req, err := client.newRequest(ctx, http.MethodGet, scope, name)
+	if err != nil {
+		return ScheduledAction{}, err
+	}
+
+	resp, err := client.internal.Pipeline().Do(req)
+	if err != nil {
+		return ScheduledAction{}, err
+	}
+
+	if !runtime.HasStatusCode(resp, http.StatusOK) {
+		return ScheduledAction{}, newResponseError(resp)
+	}
+
+	var scheduledAction ScheduledAction
+	if err := runtime.UnmarshalAsJSON(resp, &scheduledAction); err != nil {
+		return ScheduledAction{}, err
+	}
+	return scheduledAction, nil
+}
+
+// CreateOrUpdate creates or updates the scheduled action at scope. Updating
+// requires the latest eTag of the scheduled action in scheduledAction.ETag.
+//   - scope - The scope associated with scheduled action operations, e.g. 'subscriptions/{subscriptionId}'.
+//   - name - Scheduled action name.
+func (client *ScheduledActionsClient) CreateOrUpdate(ctx context.Context, scope string, name string, scheduledAction ScheduledAction) (ScheduledAction, error) {
+	req, err := client.newRequest(ctx, http.MethodPut, scope, name)
+	if err != nil {
+		return ScheduledAction{}, err
+	}
+	if err := runtime.MarshalAsJSON(req, scheduledAction); err != nil {
+		return ScheduledAction{}, err
+	}
+
+	resp, err := client.internal.Pipeline().Do(req)
+	if err != nil {
+		return ScheduledAction{}, err
+	}
+
+	if !runtime.HasStatusCode(resp, http.StatusOK, http.StatusCreated) {
+		return ScheduledAction{}, newResponseError(resp)
+	}
+
+	var result ScheduledAction
+	if err := runtime.UnmarshalAsJSON(resp, &result); err != nil {
+		return ScheduledAction{}, err
+	}
+	return result, nil
+}
+
+// Delete the scheduled action at scope. A scheduled action that does not
+// exist is treated as already deleted.
+//   - scope - The scope associated with scheduled action operations, e.g. 'subscriptions/{subscriptionId}'.
+//   - name - Scheduled action name.
+func (client *ScheduledActionsClient) Delete(ctx context.Context, scope string, name string) error {
+	req, err := client.newRequest(ctx, http.MethodDelete, scope, name)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.internal.Pipeline().Do(req)
+	if err != nil {
+		return err
+	}
+
+	if !runtime.HasStatusCode(resp, http.StatusOK, http.StatusNoContent, http.StatusNotFound) {
+		return newResponseError(resp)
+	}
+	return nil
+}
+
+func (client *ScheduledActionsClient) newRequest(ctx context.Context, method string, scope string, name string) (*policy.Request, error) {
+	urlPath := "/{scope}/providers/Microsoft.CostManagement/scheduledActions/{name}"
+	if name == "" {
+		return nil, errors.New("parameter name cannot be empty")
+	}
+	urlPath = strings.ReplaceAll(urlPath, "{scope}", strings.Trim(scope, "/"))
+	urlPath = strings.ReplaceAll(urlPath, "{name}", url.PathEscape(name))
+	req, err := runtime.NewRequest(ctx, method, runtime.JoinPaths(client.internal.Endpoint(), urlPath))
+	if err != nil {
+		return nil, err
+	}
+	reqQP := req.Raw().URL.Query()
+	reqQP.Set("api-version", "2023-03-01")
+	req.Raw().URL.RawQuery = reqQP.Encode()
+	req.Raw().Header["Accept"] = []string{"application/json"}
+	return req, nil
+}
@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package subscriptions
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestScheduledActionsClient_CreateOrUpdate(t *testing.T) {
+	client, err := NewScheduledActionsClient(staticCredential{}, newTestClientOptions(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.CostManagement/scheduledActions/anomalies" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decoding request: %s", err)
+		}
+		schedule := body["properties"].(map[string]any)["schedule"].(map[string]any)
+		if body["kind"] != "InsightAlert" || schedule["hourOfDay"] != float64(0) || schedule["dayOfMonth"] != nil {
+			t.Errorf("unexpected request body: %v", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	if err != nil {
+		t.Fatalf("creating scheduled actions client: %s", err)
+	}
+
+	hourOfDay := int32(0)
+	got, err := client.CreateOrUpdate(context.Background(), "/subscriptions/00000000-0000-0000-0000-000000000000", "anomalies", ScheduledAction{
+		Kind: ScheduledActionKindInsightAlert,
+		Properties: ScheduledActionProperties{
+			DisplayName:  "Daily anomalies",
+			Status:       ScheduledActionStatusEnabled,
+			ViewID:       "/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.CostManagement/views/ms:DailyAnomalyByResourceGroup",
+			Notification: NotificationProperties{Subject: "Cost anomaly detected", To: []string{"finops@example.com"}},
+			Schedule: ScheduleProperties{
+				Frequency: ScheduleFrequencyDaily,
+				HourOfDay: &hourOfDay,
+				StartDate: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+				EndDate:   time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("creating scheduled action: %s", err)
+	}
+	if got.Kind != ScheduledActionKindInsightAlert || got.Properties.Schedule.HourOfDay == nil || *got.Properties.Schedule.HourOfDay != 0 {
+		t.Fatalf("unexpected scheduled action: %+v", got)
+	}
+}
@@ -5,7 +5,10 @@ package subscriptions
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
@@ -16,10 +19,13 @@ import (
 // SettingsClient contains the methods for the Operations group.
 // Don't use this type directly, use NewOperationsClient() instead.
 type SettingsClient struct {
-	internal *arm.Client
+	internal       *arm.Client
+	subscriptionID string
+	scope          string
 }
 
 // NewSettingsClient creates a new instance of SettingsClient with the specified values.
+//   - subscriptionID - The ID of the target subscription.
 //   - credential - used to authorize requests. Usually a credential from azidentity.
 //   - options - pass nil to accept the default values.
 func NewSettingsClient(subscriptionID string, credential azcore.TokenCredential, options *arm.ClientOptions) (*SettingsClient, error) {
@@ -28,11 +34,34 @@ func NewSettingsClient(subscriptionID string, credential azcore.TokenCredential,
 		return nil, err
 	}
 	client := &SettingsClient{
-		internal: cl,
+		internal:       cl,
+		subscriptionID: subscriptionID,
 	}
 	return client, nil
 }
 
+// WithSubscription returns a SettingsClient targeting subscriptionID that
+// shares the pipeline of client.
+func (client *SettingsClient) WithSubscription(subscriptionID string) *SettingsClient {
+	return &SettingsClient{
+		internal:       client.internal,
+		subscriptionID: subscriptionID,
+	}
+}
+
+// WithScope returns a SettingsClient targeting scope that shares the pipeline
+// of client.
+//   - scope - The scope associated with the setting. This includes 'subscriptions/{subscriptionId}' for subscription scope,
+//     'providers/Microsoft.Billing/billingAccounts/{billingAccountId}' for Billing Account scope
+//     and 'providers/Microsoft.Billing/billingAccounts/{billingAccountId}/billingProfiles/{billingProfileId}' for Billing Profile scope.
+func (client *SettingsClient) WithScope(scope string) *SettingsClient {
+	return &SettingsClient{
+		internal:       client.internal,
+		subscriptionID: client.subscriptionID,
+		scope:          scope,
+	}
+}
+
 type TagInheritanceProperties struct {
 	PreferContainerTags bool `json:"preferContainerTags"`
 }
@@ -65,6 +94,10 @@ func (client *SettingsClient) GetTagInheritance(ctx context.Context) (TagInherit
 		return TagInheritanceResponse{}, err
 	}
 
+	if !runtime.HasStatusCode(resp, http.StatusOK) {
+		return TagInheritanceResponse{}, newResponseError(resp)
+	}
+
 	return client.handleTagInheritanceResponse(resp)
 }
 
@@ -79,25 +112,37 @@ func (client *SettingsClient) EnableTagInheritance(ctx context.Context, preferCo
 		return TagInheritanceResponse{}, err
 	}
 
+	if !runtime.HasStatusCode(resp, http.StatusOK, http.StatusCreated) {
+		return TagInheritanceResponse{}, newResponseError(resp)
+	}
+
 	return client.handleTagInheritanceResponse(resp)
 }
 
-func (client *SettingsClient) DisableTagInheritance(ctx context.Context) (TagInheritanceResponse, error) {
-	req, err := client.createTagInheritanceRequest(ctx, false)
+// DisableTagInheritance deletes the tag inheritance setting. A setting that
+// does not exist is treated as already disabled.
+func (client *SettingsClient) DisableTagInheritance(ctx context.Context) error {
+	req, err := client.deleteTagInheritanceRequest(ctx)
 	if err != nil {
-		return TagInheritanceResponse{}, err
+		return err
 	}
 
 	resp, err := client.internal.Pipeline().Do(req)
 	if err != nil {
-		return TagInheritanceResponse{}, err
+		return err
 	}
 
-	return client.handleTagInheritanceResponse(resp)
+	if !runtime.HasStatusCode(resp, http.StatusOK, http.StatusNoContent, http.StatusNotFound) {
+		return newResponseError(resp)
+	}
+	return nil
 }
 
 func (client *SettingsClient) getTagInheritanceRequest(ctx context.Context) (*policy.Request, error) {
-	urlPath := "/providers/Microsoft.CostManagement/settings/taginheritance"
+	urlPath, err := client.tagInheritancePath()
+	if err != nil {
+		return nil, err
+	}
 	req, err := runtime.NewRequest(ctx, http.MethodGet, runtime.JoinPaths(client.internal.Endpoint(), urlPath))
 	if err != nil {
 		return nil, err
@@ -109,8 +154,24 @@ func (client *SettingsClient) getTagInheritanceRequest(ctx context.Context) (*po
 	return req, nil
 }
 
+func (client *SettingsClient) deleteTagInheritanceRequest(ctx context.Context) (*policy.Request, error) {
+	urlPath, err := client.tagInheritancePath()
+	if err != nil {
+		return nil, err
+	}
+	req, err := runtime.NewRequest(ctx, http.MethodDelete, runtime.JoinPaths(client.internal.Endpoint(), urlPath))
+	if err != nil {
+		return nil, err
+	}
+	reqQP := req.Raw().URL.Query()
+	reqQP.Set("api-version", "2022-10-01-preview")
+	req.Raw().URL.RawQuery = reqQP.Encode()
+	req.Raw().Header["Accept"] = []string{"application/json"}
+	return req, nil
+}
+
 // createTagInheritanceRequest
-// https://management.azure.com/`subscription`s/a4c52fbc-96a6-43f5-b093-2188b94952a6/providers/Microsoft.CostManagement/settings/taginheritance?api-version=2022-10-01-preview
+// https://management.azure.com/subscriptions/a4c52fbc-96a6-43f5-b093-2188b94952a6/providers/Microsoft.CostManagement/settings/taginheritance?api-version=2022-10-01-preview
 func (client *SettingsClient) createTagInheritanceRequest(ctx context.Context, preferContainerTags bool) (*policy.Request, error) {
 	params := TagInheritanceRequest{
 		Kind: "taginheritance",
@@ -118,7 +179,10 @@ func (client *SettingsClient) createTagInheritanceRequest(ctx context.Context, p
 			PreferContainerTags: preferContainerTags,
 		},
 	}
-	urlPath := "/providers/Microsoft.CostManagement/settings/taginheritance"
+	urlPath, err := client.tagInheritancePath()
+	if err != nil {
+		return nil, err
+	}
 	req, err := runtime.NewRequest(ctx, http.MethodPut, runtime.JoinPaths(client.internal.Endpoint(), urlPath))
 	if err != nil {
 		return nil, err
@@ -130,6 +194,19 @@ func (client *SettingsClient) createTagInheritanceRequest(ctx context.Context, p
 	return req, runtime.MarshalAsJSON(req, params)
 }
 
+func (client *SettingsClient) tagInheritancePath() (string, error) {
+	if client.scope != "" {
+		urlPath := "/{scope}/providers/Microsoft.CostManagement/settings/taginheritance"
+		return strings.ReplaceAll(urlPath, "{scope}", strings.Trim(client.scope, "/")), nil
+	}
+
+	urlPath := "/subscriptions/{subscriptionId}/providers/Microsoft.CostManagement/settings/taginheritance"
+	if client.subscriptionID == "" {
+		return "", errors.New("parameter client.subscriptionID cannot be empty")
+	}
+	return strings.ReplaceAll(urlPath, "{subscriptionId}", url.PathEscape(client.subscriptionID)), nil
+}
+
 func (client *SettingsClient) handleTagInheritanceResponse(resp *http.Response) (TagInheritanceResponse, error) {
 	var TagInheritance TagInheritanceResponse
 
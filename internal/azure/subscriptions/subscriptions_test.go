@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package subscriptions
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+type staticCredential struct{}
+
+func (staticCredential) GetToken(context.Context, policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	return azcore.AccessToken{Token: "token", ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
+
+// newTestClientOptions starts a server running handler and returns client
+// options sending every request to it.
+func newTestClientOptions(t *testing.T, handler http.HandlerFunc) *arm.ClientOptions {
+	t.Helper()
+
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+
+	return &arm.ClientOptions{
+		ClientOptions: policy.ClientOptions{
+			Cloud: cloud.Configuration{
+				Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+					cloud.ResourceManager: {Endpoint: server.URL, Audience: server.URL},
+				},
+			},
+			Retry:     policy.RetryOptions{MaxRetries: -1},
+			Transport: server.Client(),
+		},
+	}
+}
+
+// newTestSettingsClient returns a SettingsClient talking to handler.
+func newTestSettingsClient(t *testing.T, handler http.HandlerFunc) *SettingsClient {
+	t.Helper()
+
+	client, err := NewSettingsClient("00000000-0000-0000-0000-000000000000", staticCredential{}, newTestClientOptions(t, handler))
+	if err != nil {
+		t.Fatalf("creating settings client: %s", err)
+	}
+	return client
+}
+
+func TestSettingsClient_GetTagInheritance(t *testing.T) {
+	client := newTestSettingsClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if want := "/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.CostManagement/settings/taginheritance"; r.URL.Path != want {
+			t.Errorf("expected path %q, got %q", want, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.CostManagement/settings/taginheritance","properties":{"preferContainerTags":true}}`))
+	})
+
+	got, err := client.GetTagInheritance(context.Background())
+	if err != nil {
+		t.Fatalf("getting tag inheritance: %s", err)
+	}
+	if got.Id == "" || !got.Properties.PreferContainerTags {
+		t.Fatalf("unexpected response: %+v", got)
+	}
+}
+
+func TestSettingsClient_responseError(t *testing.T) {
+	client := newTestSettingsClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":{"code":"AuthorizationFailed","message":"The client does not have authorization.","target":"taginheritance","details":[{"code":"RBACAccessDenied","message":"Missing Cost Management Contributor."}]}}`))
+	})
+
+	_, err := client.WithScope("/providers/Microsoft.Billing/billingAccounts/1234").EnableTagInheritance(context.Background(), false)
+	if err == nil {
+		t.Fatal("expected an error for a 403 response")
+	}
+
+	var respErr *ResponseError
+	if !errors.As(err, &respErr) {
+		t.Fatalf("expected a *ResponseError, got %T", err)
+	}
+	if respErr.Detail.Code != "AuthorizationFailed" || respErr.Detail.Target != "taginheritance" || len(respErr.Detail.Details) != 1 {
+		t.Fatalf("unexpected error detail: %+v", respErr.Detail)
+	}
+	if !strings.Contains(err.Error(), "Missing Cost Management Contributor.") {
+		t.Fatalf("expected the error details in the message, got %q", err.Error())
+	}
+
+	var azErr *azcore.ResponseError
+	if !errors.As(err, &azErr) || azErr.StatusCode != http.StatusForbidden || azErr.ErrorCode != "AuthorizationFailed" {
+		t.Fatalf("expected an *azcore.ResponseError with status 403, got %v", err)
+	}
+	if IsNotFound(err) {
+		t.Fatal("did not expect a 403 to be reported as not found")
+	}
+}
+
+func TestSettingsClient_notFound(t *testing.T) {
+	client := newTestSettingsClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	_, err := client.GetTagInheritance(context.Background())
+	if !IsNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
+
+func TestSettingsClient_DisableTagInheritance(t *testing.T) {
+	for _, status := range []int{http.StatusOK, http.StatusNoContent, http.StatusNotFound} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			client := newTestSettingsClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodDelete {
+					t.Errorf("expected a DELETE, got %s", r.Method)
+				}
+				w.WriteHeader(status)
+			})
+
+			if err := client.DisableTagInheritance(context.Background()); err != nil {
+				t.Fatalf("disabling tag inheritance: %s", err)
+			}
+		})
+	}
+
+	client := newTestSettingsClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	if err := client.DisableTagInheritance(context.Background()); err == nil {
+		t.Fatal("expected an error for a 403 response")
+	}
+}
@@ -0,0 +1,284 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package subscriptions
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+// ViewsClient contains the methods for the Views group.
+// Don't use this type directly, use NewViewsClient() instead.
+type ViewsClient struct {
+	internal *arm.Client
+}
+
+// NewViewsClient creates a new instance of ViewsClient with the specified values.
+//   - credential - used to authorize requests. Usually a credential from azidentity.
+//   - options - pass nil to accept the default values.
+func NewViewsClient(credential azcore.TokenCredential, options *arm.ClientOptions) (*ViewsClient, error) {
+	cl, err := arm.NewClient(moduleName+".ViewsClient", moduleVersion, credential, options)
+	if err != nil {
+		return nil, err
+	}
+	client := &ViewsClient{
+		internal: cl,
+	}
+	return client, nil
+}
+
+// View - States and configurations of Cost Analysis.
+type View struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+	Type string `json:"type,omitempty"`
+
+	// eTag of the resource. To handle concurrent update scenario, this field will be used to determine whether the user is
+	// updating the latest version or not.
+	ETag string `json:"eTag,omitempty"`
+
+	// The properties of the view.
+	Properties ViewProperties `json:"properties"`
+}
+
+// ViewProperties - The properties of the view.
+type ViewProperties struct {
+	// User input name of the view. Required.
+	DisplayName string `json:"displayName,omitempty"`
+
+	// Cost Management scope to save the view on, e.g. 'subscriptions/{subscriptionId}'.
+	Scope string `json:"scope,omitempty"`
+
+	// Show costs accumulated over time.
+	Accumulated AccumulatedType `json:"accumulated,omitempty"`
+
+	// Chart type of the main view in Cost Analysis. Required.
+	Chart ChartType `json:"chart,omitempty"`
+
+	// List of KPIs to show in Cost Analysis UI.
+	Kpis []KpiProperties `json:"kpis,omitempty"`
+
+	// Metric to use when displaying costs.
+	Metric MetricType `json:"metric,omitempty"`
+
+	// Configuration of 3 sub-views in the Cost Analysis UI.
+	Pivots []PivotProperties `json:"pivots,omitempty"`
+
+	// Query body configuration. Required.
+	Query *ReportConfigDefinition `json:"query,omitempty"`
+
+	// READ-ONLY; Date the user created this view.
+	CreatedOn *time.Time `json:"createdOn,omitempty"`
+
+	// READ-ONLY; Currency of the current view.
+	Currency string `json:"currency,omitempty"`
+
+	// READ-ONLY; Date the user last modified this view.
+	ModifiedOn *time.Time `json:"modifiedOn,omitempty"`
+}
+
+// KpiProperties - Each KPI must contain a 'type' and 'enabled' key.
+type KpiProperties struct {
+	// show the KPI in the UI?
+	Enabled bool `json:"enabled"`
+
+	// ID of resource related to metric (budget).
+	ID string `json:"id,omitempty"`
+
+	// KPI type (Forecast, Budget).
+	Type KpiType `json:"type"`
+}
+
+// PivotProperties - Each pivot must contain a 'type' and 'name'.
+type PivotProperties struct {
+	// Data field to show in view.
+	Name string `json:"name"`
+
+	// Data type to show in view.
+	Type PivotType `json:"type"`
+}
+
+// ReportConfigDefinition - The definition of a report config.
+type ReportConfigDefinition struct {
+	// The time frame for pulling data for the report. If custom, then a specific time period must be provided.
+	Timeframe ReportTimeframeType `json:"timeframe"`
+
+	// The type of the report. Usage represents actual usage, forecast represents forecasted data and UsageAndForecast represents
+	// both usage and forecasted data. Actual usage and forecasted data can be differentiated based on dates.
+	Type ReportType `json:"type"`
+
+	// Has definition for data in this report config.
+	DataSet *ReportConfigDataset `json:"dataSet,omitempty"`
+
+	// Has time period for pulling data for the report.
+	TimePeriod *ReportConfigTimePeriod `json:"timePeriod,omitempty"`
+}
+
+// ReportConfigDataset - The definition of data present in the report.
+type ReportConfigDataset struct {
+	// Dictionary of aggregation expression to use in the report. The key of each item in the dictionary is the alias for the
+	// aggregated column. Report can have up to 2 aggregation clauses.
+	Aggregation map[string]ReportConfigAggregation `json:"aggregation,omitempty"`
+
+	// Has configuration information for the data in the report. The configuration will be ignored if aggregation and grouping
+	// are provided.
+	Configuration *ReportConfigDatasetConfiguration `json:"configuration,omitempty"`
+
+	// The granularity of rows in the report.
+	Granularity ReportGranularityType `json:"granularity,omitempty"`
+
+	// Array of group by expression to use in the report. Report can have up to 2 group by clauses.
+	Grouping []ReportConfigGrouping `json:"grouping,omitempty"`
+
+	// Array of order by expression to use in the report.
+	Sorting []ReportConfigSorting `json:"sorting,omitempty"`
+}
+
+// ReportConfigAggregation - The aggregation expression to be used in the report.
+type ReportConfigAggregation struct {
+	// The name of the aggregation function to use.
+	Function FunctionType `json:"function"`
+
+	// The name of the column to aggregate.
+	Name string `json:"name"`
+}
+
+// ReportConfigDatasetConfiguration - The configuration of dataset in the report.
+type ReportConfigDatasetConfiguration struct {
+	// Array of column names to be included in the report. Any valid report column name is allowed. If not provided, then report
+	// includes all columns.
+	Columns []string `json:"columns,omitempty"`
+}
+
+// ReportConfigGrouping - The group by expression to be used in the report.
+type ReportConfigGrouping struct {
+	// The name of the column to group. This version supports subscription lowest possible grain.
+	Name string `json:"name"`
+
+	// Has type of the column to group.
+	Type QueryColumnType `json:"type"`
+}
+
+// ReportConfigSorting - The order by expression to be used in the report.
+type ReportConfigSorting struct {
+	// The name of the column to sort.
+	Name string `json:"name"`
+
+	// Direction of sort.
+	Direction ReportConfigSortingType `json:"direction,omitempty"`
+}
+
+// ReportConfigTimePeriod - The start and end date for pulling data for the report.
+type ReportConfigTimePeriod struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// Get the view for the defined scope by view name.
+//   - scope - The scope associated with view operations, e.g. 'subscriptions/{subscriptionId}', or "" for a view shared
+//     with the whole tenant.
+//   - viewName - View name.
+func (client *ViewsClient) Get(ctx context.Context, scope string, viewName string) (View, error) {
+	req, err := client.newRequest(ctx, http.MethodGet, scope, viewName)
+	if err != nil {
+		return View{}, err
+	}
+
+	resp, err := client.internal.Pipeline().Do(req)
+	if err != nil {
+		return View{}, err
+	}
+
+	if !runtime.HasStatusCode(resp, http.StatusOK) {
+		return View{}, newResponseError(resp)
+	}
+
+	var view View
+	if err := runtime.UnmarshalAsJSON(resp, &view); err != nil {
+		return View{}, err
+	}
+	return view, nil
+}
+
+// CreateOrUpdate creates or updates the view. Updating requires the latest
+// eTag of the view in parameters.ETag.
+//   - scope - The scope associated with view operations, e.g. 'subscriptions/{subscriptionId}', or "" for a view shared
+//     with the whole tenant.
+//   - viewName - View name.
+func (client *ViewsClient) CreateOrUpdate(ctx context.Context, scope string, viewName string, parameters View) (View, error) {
+	req, err := client.newRequest(ctx, http.MethodPut, scope, viewName)
+	if err != nil {
+		return View{}, err
+	}
+	if err := runtime.MarshalAsJSON(req, parameters); err != nil {
+		return View{}, err
+	}
+
+	resp, err := client.internal.Pipeline().Do(req)
+	if err != nil {
+		return View{}, err
+	}
+
+	if !runtime.HasStatusCode(resp, http.StatusOK, http.StatusCreated) {
+		return View{}, newResponseError(resp)
+	}
+
+	var view View
+	if err := runtime.UnmarshalAsJSON(resp, &view); err != nil {
+		return View{}, err
+	}
+	return view, nil
+}
+
+// Delete the view. A view that does not exist is treated as already deleted.
+//   - scope - The scope associated with view operations, e.g. 'subscriptions/{subscriptionId}', or "" for a view shared
+//     with the whole tenant.
+//   - viewName - View name.
+func (client *ViewsClient) Delete(ctx context.Context, scope string, viewName string) error {
+	req, err := client.newRequest(ctx, http.MethodDelete, scope, viewName)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.internal.Pipeline().Do(req)
+	if err != nil {
+		return err
+	}
+
+	if !runtime.HasStatusCode(resp, http.StatusOK, http.StatusNoContent, http.StatusNotFound) {
+		return newResponseError(resp)
+	}
+	return nil
+}
+
+func (client *ViewsClient) newRequest(ctx context.Context, method string, scope string, viewName string) (*policy.Request, error) {
+	urlPath := "/{scope}/providers/Microsoft.CostManagement/views/{viewName}"
+	if viewName == "" {
+		return nil, errors.New("parameter viewName cannot be empty")
+	}
+	if strings.Trim(scope, "/") == "" {
+		// Views shared with the whole tenant are saved without a scope
+		urlPath = "/providers/Microsoft.CostManagement/views/{viewName}"
+	}
+	urlPath = strings.ReplaceAll(urlPath, "{scope}", strings.Trim(scope, "/"))
+	urlPath = strings.ReplaceAll(urlPath, "{viewName}", url.PathEscape(viewName))
+	req, err := runtime.NewRequest(ctx, method, runtime.JoinPaths(client.internal.Endpoint(), urlPath))
+	if err != nil {
+		return nil, err
+	}
+	reqQP := req.Raw().URL.Query()
+	reqQP.Set("api-version", "2023-03-01")
+	req.Raw().URL.RawQuery = reqQP.Encode()
+	req.Raw().Header["Accept"] = []string{"application/json"}
+	return req, nil
+}
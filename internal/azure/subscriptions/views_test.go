@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package subscriptions
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestViewsClient_Get(t *testing.T) {
+	client, err := NewViewsClient(staticCredential{}, newTestClientOptions(t, func(w http.ResponseWriter, r *http.Request) {
+		if want := "/providers/Microsoft.Management/managementGroups/finops/providers/Microsoft.CostManagement/views/by-service"; r.URL.Path != want {
+			t.Errorf("expected path %q, got %q", want, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+  "id": "/providers/Microsoft.Management/managementGroups/finops/providers/Microsoft.CostManagement/views/by-service",
+  "name": "by-service",
+  "eTag": "\"1d4ff9fe66f1d10\"",
+  "properties": {
+    "displayName": "Costs by service",
+    "chart": "StackedColumn",
+    "accumulated": "true",
+    "metric": "ActualCost",
+    "currency": "USD",
+    "query": {
+      "type": "Usage",
+      "timeframe": "MonthToDate",
+      "dataSet": {
+        "granularity": "Daily",
+        "aggregation": {"totalCost": {"name": "PreTaxCost", "function": "Sum"}},
+        "grouping": [{"type": "Dimension", "name": "ServiceName"}]
+      }
+    },
+    "kpis": [{"type": "Forecast", "enabled": true}],
+    "pivots": [{"type": "TagKey", "name": "team"}]
+  }
+}`))
+	}))
+	if err != nil {
+		t.Fatalf("creating views client: %s", err)
+	}
+
+	got, err := client.Get(context.Background(), "/providers/Microsoft.Management/managementGroups/finops", "by-service")
+	if err != nil {
+		t.Fatalf("getting view: %s", err)
+	}
+	if got.Properties.Chart != ChartTypeStackedColumn || got.Properties.Accumulated != AccumulatedTypeTrue || got.Properties.Query == nil || got.Properties.Query.DataSet == nil {
+		t.Fatalf("unexpected view: %+v", got)
+	}
+	if got.Properties.Query.DataSet.Aggregation["totalCost"].Name != "PreTaxCost" || got.Properties.Query.DataSet.Grouping[0].Type != QueryColumnTypeDimension {
+		t.Fatalf("unexpected dataset: %+v", got.Properties.Query.DataSet)
+	}
+	if len(got.Properties.Kpis) != 1 || got.Properties.Kpis[0].Type != KpiTypeForecast || got.Properties.Pivots[0].Type != PivotTypeTagKey {
+		t.Fatalf("unexpected kpis %+v and pivots %+v", got.Properties.Kpis, got.Properties.Pivots)
+	}
+}
+
+func TestViewsClient_GetShared(t *testing.T) {
+	client, err := NewViewsClient(staticCredential{}, newTestClientOptions(t, func(w http.ResponseWriter, r *http.Request) {
+		if want := "/providers/Microsoft.CostManagement/views/by-service"; r.URL.Path != want {
+			t.Errorf("expected path %q, got %q", want, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+  "id": "/providers/Microsoft.CostManagement/views/by-service",
+  "name": "by-service",
+  "eTag": "\"1d4ff9fe66f1d10\"",
+  "properties": {"displayName": "Costs by service", "chart": "Table"}
+}`))
+	}))
+	if err != nil {
+		t.Fatalf("creating views client: %s", err)
+	}
+
+	got, err := client.Get(context.Background(), "", "by-service")
+	if err != nil {
+		t.Fatalf("getting view: %s", err)
+	}
+	if got.Properties.DisplayName != "Costs by service" || got.Properties.Scope != "" {
+		t.Fatalf("unexpected view: %+v", got)
+	}
+}
@@ -0,0 +1,290 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	subscriptionSettings "github.com/ekristen/terraform-provider-azurex/internal/azure/subscriptions"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &BenefitRecommendationsDataSource{}
+
+func NewBenefitRecommendationsDataSource() datasource.DataSource {
+	return &BenefitRecommendationsDataSource{}
+}
+
+// BenefitRecommendationsDataSource defines the data source implementation.
+type BenefitRecommendationsDataSource struct {
+	BenefitRecommendationsClient *subscriptionSettings.BenefitRecommendationsClient
+}
+
+// BenefitRecommendationsDataSourceModel describes the data source data model.
+type BenefitRecommendationsDataSourceModel struct {
+	Scope               types.String `tfsdk:"scope"`
+	LookBackPeriod      types.String `tfsdk:"look_back_period"`
+	Term                types.String `tfsdk:"term"`
+	RecommendationScope types.String `tfsdk:"recommendation_scope"`
+	Recommendations     types.List   `tfsdk:"recommendations"`
+}
+
+// benefitRecommendationModel is a single entry of recommendations.
+type benefitRecommendationModel struct {
+	ID                           types.String  `tfsdk:"id"`
+	Name                         types.String  `tfsdk:"name"`
+	Kind                         types.String  `tfsdk:"kind"`
+	Scope                        types.String  `tfsdk:"scope"`
+	SubscriptionID               types.String  `tfsdk:"subscription_id"`
+	ResourceGroup                types.String  `tfsdk:"resource_group"`
+	Term                         types.String  `tfsdk:"term"`
+	LookBackPeriod               types.String  `tfsdk:"look_back_period"`
+	ArmSKUName                   types.String  `tfsdk:"arm_sku_name"`
+	CommitmentGranularity        types.String  `tfsdk:"commitment_granularity"`
+	CommitmentAmount             types.Float64 `tfsdk:"commitment_amount"`
+	CurrencyCode                 types.String  `tfsdk:"currency_code"`
+	CostWithoutBenefit           types.Float64 `tfsdk:"cost_without_benefit"`
+	BenefitCost                  types.Float64 `tfsdk:"benefit_cost"`
+	TotalCost                    types.Float64 `tfsdk:"total_cost"`
+	SavingsAmount                types.Float64 `tfsdk:"savings_amount"`
+	SavingsPercentage            types.Float64 `tfsdk:"savings_percentage"`
+	CoveragePercentage           types.Float64 `tfsdk:"coverage_percentage"`
+	AverageUtilizationPercentage types.Float64 `tfsdk:"average_utilization_percentage"`
+	OverageCost                  types.Float64 `tfsdk:"overage_cost"`
+	WastageCost                  types.Float64 `tfsdk:"wastage_cost"`
+	TotalHours                   types.Int64   `tfsdk:"total_hours"`
+	FirstConsumptionDate         types.String  `tfsdk:"first_consumption_date"`
+	LastConsumptionDate          types.String  `tfsdk:"last_consumption_date"`
+}
+
+var benefitRecommendationAttrTypes = map[string]attr.Type{
+	"id":                             types.StringType,
+	"name":                           types.StringType,
+	"kind":                           types.StringType,
+	"scope":                          types.StringType,
+	"subscription_id":                types.StringType,
+	"resource_group":                 types.StringType,
+	"term":                           types.StringType,
+	"look_back_period":               types.StringType,
+	"arm_sku_name":                   types.StringType,
+	"commitment_granularity":         types.StringType,
+	"commitment_amount":              types.Float64Type,
+	"currency_code":                  types.StringType,
+	"cost_without_benefit":           types.Float64Type,
+	"benefit_cost":                   types.Float64Type,
+	"total_cost":                     types.Float64Type,
+	"savings_amount":                 types.Float64Type,
+	"savings_percentage":             types.Float64Type,
+	"coverage_percentage":            types.Float64Type,
+	"average_utilization_percentage": types.Float64Type,
+	"overage_cost":                   types.Float64Type,
+	"wastage_cost":                   types.Float64Type,
+	"total_hours":                    types.Int64Type,
+	"first_consumption_date":         types.StringType,
+	"last_consumption_date":          types.StringType,
+}
+
+func (d *BenefitRecommendationsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_benefit_recommendations"
+}
+
+func (d *BenefitRecommendationsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	computedString := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{MarkdownDescription: description, Computed: true}
+	}
+	computedFloat := func(description string) schema.Float64Attribute {
+		return schema.Float64Attribute{MarkdownDescription: description, Computed: true}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the commitments, such as savings plans, Cost Management recommends for a scope based on its usage over a look-back period",
+
+		Attributes: map[string]schema.Attribute{
+			"scope": schema.StringAttribute{
+				MarkdownDescription: "Scope to list the recommendations of, a billing account or billing profile for shared recommendations, " +
+					"a subscription (`/subscriptions/{subscriptionId}`) or a resource group for single scope recommendations",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(costScopePattern, "must be a subscription, resource group, management group or billing scope"),
+				},
+			},
+			"look_back_period": schema.StringAttribute{
+				MarkdownDescription: "Usage the recommendations are based on, `Last7Days`, `Last30Days` or `Last60Days`. Azure defaults to `Last60Days`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(enumValues(subscriptionSettings.PossibleLookBackPeriodValues())...),
+				},
+			},
+			"term": schema.StringAttribute{
+				MarkdownDescription: "Term of the recommended commitments, `P1Y` or `P3Y`. Azure defaults to `P3Y`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(enumValues(subscriptionSettings.PossibleTermValues())...),
+				},
+			},
+			"recommendation_scope": schema.StringAttribute{
+				MarkdownDescription: "Scope of the recommended commitments, `Shared` across the billing scope or `Single` subscriptions and resource groups. " +
+					"Azure defaults to `Shared`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(enumValues(subscriptionSettings.PossibleScopeValues())...),
+				},
+			},
+			"recommendations": schema.ListNestedAttribute{
+				MarkdownDescription: "Recommended commitments",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":                             computedString("ID of the recommendation"),
+						"name":                           computedString("Name of the recommendation"),
+						"kind":                           computedString("Kind of the recommended benefit, e.g. `SavingsPlan`"),
+						"scope":                          computedString("Scope of the recommended commitment, `Shared` or `Single`"),
+						"subscription_id":                computedString("Subscription of a `Single` scope recommendation"),
+						"resource_group":                 computedString("Resource group of a `Single` scope recommendation"),
+						"term":                           computedString("Term of the recommended commitment, e.g. `P1Y`"),
+						"look_back_period":               computedString("Usage the recommendation is based on, e.g. `Last60Days`"),
+						"arm_sku_name":                   computedString("ARM SKU name of the recommended benefit"),
+						"commitment_granularity":         computedString("Period `commitment_amount` is committed per, e.g. `Hourly`"),
+						"commitment_amount":              computedFloat("Recommended commitment per `commitment_granularity`"),
+						"currency_code":                  computedString("ISO 4217 currency of the costs and savings"),
+						"cost_without_benefit":           computedFloat("Cost of the look-back period without the benefit"),
+						"benefit_cost":                   computedFloat("Estimated cost of the benefit over the look-back period"),
+						"total_cost":                     computedFloat("Estimated total cost of the look-back period with the benefit"),
+						"savings_amount":                 computedFloat("Estimated savings over the look-back period"),
+						"savings_percentage":             computedFloat("Estimated savings over the look-back period, in percent"),
+						"coverage_percentage":            computedFloat("Estimated share of the usage covered by the benefit, in percent"),
+						"average_utilization_percentage": computedFloat("Estimated average utilization of the benefit, in percent"),
+						"overage_cost":                   computedFloat("Estimated cost of usage not covered by the benefit"),
+						"wastage_cost":                   computedFloat("Estimated cost of the unused part of the benefit"),
+						"total_hours": schema.Int64Attribute{
+							MarkdownDescription: "Hours of usage the recommendation is based on",
+							Computed:            true,
+						},
+						"first_consumption_date": computedString("Start of the usage the recommendation is based on"),
+						"last_consumption_date":  computedString("End of the usage the recommendation is based on"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *BenefitRecommendationsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(AzurexContext)
+	if !ok {
+		resp.Diagnostics.AddError("unable to obtain provider data", "provider data not available")
+		return
+	}
+
+	benefitRecommendationsClient, err := subscriptionSettings.NewBenefitRecommendationsClient(data.IdentityCreds, data.ClientOptions)
+	if err != nil {
+		resp.Diagnostics.AddError("unable to configure benefit recommendations client", fmt.Sprintf("got: %s", err.Error()))
+		return
+	}
+	d.BenefitRecommendationsClient = benefitRecommendationsClient
+}
+
+func (d *BenefitRecommendationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data BenefitRecommendationsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var filters []string
+	for _, filter := range []struct {
+		property string
+		value    types.String
+	}{
+		{"properties/lookBackPeriod", data.LookBackPeriod},
+		{"properties/term", data.Term},
+		{"properties/scope", data.RecommendationScope},
+	} {
+		if !filter.value.IsNull() {
+			filters = append(filters, fmt.Sprintf("%s eq '%s'", filter.property, filter.value.ValueString()))
+		}
+	}
+
+	scope := strings.TrimSuffix(data.Scope.ValueString(), "/")
+	recommendations, err := d.BenefitRecommendationsClient.List(ctx, scope, strings.Join(filters, " AND "))
+	if err != nil {
+		resp.Diagnostics.Append(responseErrorDiagnostic("Error listing benefit recommendations", err))
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("read %d benefit recommendations for %s", len(recommendations), scope))
+
+	models := make([]benefitRecommendationModel, 0, len(recommendations))
+	for _, recommendation := range recommendations {
+		models = append(models, newBenefitRecommendationModel(recommendation))
+	}
+
+	recommendationsValue, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: benefitRecommendationAttrTypes}, models)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Recommendations = recommendationsValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func newBenefitRecommendationModel(recommendation subscriptionSettings.BenefitRecommendationModel) benefitRecommendationModel {
+	properties := recommendation.Properties
+	model := benefitRecommendationModel{
+		ID:                           types.StringValue(recommendation.ID),
+		Name:                         types.StringValue(recommendation.Name),
+		Kind:                         stringValueOrNull(string(recommendation.Kind)),
+		Scope:                        stringValueOrNull(string(properties.Scope)),
+		SubscriptionID:               stringValueOrNull(properties.SubscriptionID),
+		ResourceGroup:                stringValueOrNull(properties.ResourceGroup),
+		Term:                         stringValueOrNull(string(properties.Term)),
+		LookBackPeriod:               stringValueOrNull(string(properties.LookBackPeriod)),
+		ArmSKUName:                   stringValueOrNull(properties.ArmSKUName),
+		CommitmentGranularity:        stringValueOrNull(string(properties.CommitmentGranularity)),
+		CurrencyCode:                 stringValueOrNull(properties.CurrencyCode),
+		CostWithoutBenefit:           types.Float64Value(properties.CostWithoutBenefit),
+		TotalHours:                   types.Int64Value(int64(properties.TotalHours)),
+		FirstConsumptionDate:         costTimeValue(types.StringNull(), properties.FirstConsumptionDate),
+		LastConsumptionDate:          costTimeValue(types.StringNull(), properties.LastConsumptionDate),
+		CommitmentAmount:             types.Float64Null(),
+		BenefitCost:                  types.Float64Null(),
+		TotalCost:                    types.Float64Null(),
+		SavingsAmount:                types.Float64Null(),
+		SavingsPercentage:            types.Float64Null(),
+		CoveragePercentage:           types.Float64Null(),
+		AverageUtilizationPercentage: types.Float64Null(),
+		OverageCost:                  types.Float64Null(),
+		WastageCost:                  types.Float64Null(),
+	}
+
+	if details := properties.RecommendationDetails; details != nil {
+		model.CommitmentAmount = types.Float64Value(details.CommitmentAmount)
+		model.BenefitCost = types.Float64Value(details.BenefitCost)
+		model.TotalCost = types.Float64Value(details.TotalCost)
+		model.SavingsAmount = types.Float64Value(details.SavingsAmount)
+		model.SavingsPercentage = types.Float64Value(details.SavingsPercentage)
+		model.CoveragePercentage = types.Float64Value(details.CoveragePercentage)
+		model.AverageUtilizationPercentage = types.Float64Value(details.AverageUtilizationPercentage)
+		model.OverageCost = types.Float64Value(details.OverageCost)
+		model.WastageCost = types.Float64Value(details.WastageCost)
+	}
+	return model
+}
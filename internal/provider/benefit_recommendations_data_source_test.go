@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccBenefitRecommendationsDataSource(t *testing.T) {
+	skipWithoutTerraform(t)
+	fake := newFakeARM(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fake.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+data "azurex_benefit_recommendations" "test" {
+  scope = "/subscriptions/00000000-0000-0000-0000-000000000000"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.azurex_benefit_recommendations.test", "recommendations.#", "1"),
+					resource.TestCheckResourceAttr("data.azurex_benefit_recommendations.test", "recommendations.0.kind", "SavingsPlan"),
+					resource.TestCheckResourceAttr("data.azurex_benefit_recommendations.test", "recommendations.0.scope", "Shared"),
+					resource.TestCheckResourceAttr("data.azurex_benefit_recommendations.test", "recommendations.0.term", "P3Y"),
+					resource.TestCheckResourceAttr("data.azurex_benefit_recommendations.test", "recommendations.0.look_back_period", "Last60Days"),
+					resource.TestCheckResourceAttr("data.azurex_benefit_recommendations.test", "recommendations.0.commitment_amount", "1"),
+					resource.TestCheckResourceAttr("data.azurex_benefit_recommendations.test", "recommendations.0.savings_amount", "400"),
+					resource.TestCheckResourceAttr("data.azurex_benefit_recommendations.test", "recommendations.0.total_hours", "1440"),
+					resource.TestCheckResourceAttr("data.azurex_benefit_recommendations.test", "recommendations.0.last_consumption_date", "2029-12-31T00:00:00Z"),
+					resource.TestCheckNoResourceAttr("data.azurex_benefit_recommendations.test", "recommendations.0.subscription_id"),
+				),
+			},
+			{
+				Config: fake.providerConfig() + `
+data "azurex_benefit_recommendations" "test" {
+  scope                = "/subscriptions/00000000-0000-0000-0000-000000000000"
+  look_back_period     = "Last7Days"
+  term                 = "P1Y"
+  recommendation_scope = "Single"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.azurex_benefit_recommendations.test", "recommendations.#", "1"),
+					resource.TestCheckResourceAttr("data.azurex_benefit_recommendations.test", "recommendations.0.name", "single-p1y-last7days"),
+					resource.TestCheckResourceAttr("data.azurex_benefit_recommendations.test", "recommendations.0.term", "P1Y"),
+					resource.TestCheckResourceAttr("data.azurex_benefit_recommendations.test", "recommendations.0.look_back_period", "Last7Days"),
+					resource.TestCheckResourceAttr("data.azurex_benefit_recommendations.test", "recommendations.0.commitment_amount", "1.5"),
+					resource.TestCheckResourceAttr("data.azurex_benefit_recommendations.test", "recommendations.0.subscription_id", "00000000-0000-0000-0000-000000000000"),
+				),
+			},
+			{
+				Config: fake.providerConfig() + `
+data "azurex_benefit_recommendations" "test" {
+  scope = "/subscriptions/00000000-0000-0000-0000-000000000000"
+  term  = "P5Y"
+}
+`,
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+		},
+	})
+}
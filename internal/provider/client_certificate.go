@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"golang.org/x/crypto/pkcs12"
+)
+
+// clientCertificate is a decoded client certificate bundle.
+type clientCertificate struct {
+	data  []byte
+	certs []*x509.Certificate
+	key   crypto.PrivateKey
+}
+
+// clientCertificateError names the provider attribute holding the input that
+// could not be used.
+type clientCertificateError struct {
+	attribute string
+	err       error
+}
+
+func (e *clientCertificateError) Error() string {
+	return fmt.Sprintf("%s: %s", e.attribute, e.err)
+}
+
+func (e *clientCertificateError) Unwrap() error {
+	return e.err
+}
+
+func (e *clientCertificateError) diagnostic() diag.Diagnostic {
+	return diag.NewAttributeErrorDiagnostic(path.Root(e.attribute), "Invalid client certificate", e.err.Error()+".")
+}
+
+// loadClientCertificate decodes the inline client_certificate or reads the
+// file at client_certificate_path, then unlocks it with
+// client_certificate_password when one is set.
+func (c providerConfig) loadClientCertificate() (*clientCertificate, *clientCertificateError) {
+	var data []byte
+	source := "client_certificate_path"
+
+	if c.ClientCertificate != "" {
+		source = "client_certificate"
+
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(c.ClientCertificate))
+		if err != nil {
+			return nil, &clientCertificateError{attribute: source, err: fmt.Errorf("the value is not valid base64: %w", err)}
+		}
+		data = decoded
+	} else {
+		contents, err := os.ReadFile(c.ClientCertificatePath)
+		if err != nil {
+			return nil, &clientCertificateError{attribute: source, err: fmt.Errorf("reading %q: %w", c.ClientCertificatePath, err)}
+		}
+		data = contents
+	}
+
+	var password []byte
+	if c.ClientCertificatePassword != "" {
+		password = []byte(c.ClientCertificatePassword)
+	}
+
+	certs, key, err := azidentity.ParseCertificates(data, password)
+	if err != nil {
+		if errors.Is(err, pkcs12.ErrIncorrectPassword) {
+			return nil, &clientCertificateError{attribute: "client_certificate_password", err: fmt.Errorf("the password does not unlock the certificate from %s: %w", source, err)}
+		}
+		return nil, &clientCertificateError{attribute: source, err: fmt.Errorf("the certificate could not be decoded as a PEM or PKCS#12 bundle: %w", err)}
+	}
+
+	return &clientCertificate{data: data, certs: certs, key: key}, nil
+}
@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gopkcs12 "software.sslmate.com/src/go-pkcs12"
+)
+
+func testClientCertificatePEM(t *testing.T) []byte {
+	t.Helper()
+
+	key, cert := testClientCertificate(t)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshalling key: %s", err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	return append(certPEM, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})...)
+}
+
+// testClientCertificatePFX returns a PKCS#12 bundle locked with password.
+func testClientCertificatePFX(t *testing.T, password string) []byte {
+	t.Helper()
+
+	key, cert := testClientCertificate(t)
+	pfx, err := gopkcs12.Legacy.Encode(key, cert, nil, password)
+	if err != nil {
+		t.Fatalf("encoding PKCS#12 bundle: %s", err)
+	}
+	return pfx
+}
+
+func testClientCertificate(t *testing.T) (*rsa.PrivateKey, *x509.Certificate) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating key: %s", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "azurex-test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("creating certificate: %s", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parsing certificate: %s", err)
+	}
+	return key, cert
+}
+
+func TestProviderConfig_loadClientCertificate(t *testing.T) {
+	certPEM := testClientCertificatePEM(t)
+	certPFX := base64.StdEncoding.EncodeToString(testClientCertificatePFX(t, "secret"))
+
+	certPath := filepath.Join(t.TempDir(), "client.pem")
+	if err := os.WriteFile(certPath, certPEM, 0o600); err != nil {
+		t.Fatalf("writing certificate: %s", err)
+	}
+
+	cases := map[string]struct {
+		config    providerConfig
+		attribute string
+	}{
+		"inline": {
+			config: providerConfig{ClientCertificate: base64.StdEncoding.EncodeToString(certPEM)},
+		},
+		"path": {
+			config: providerConfig{ClientCertificatePath: certPath},
+		},
+		"pkcs12 with password": {
+			config: providerConfig{ClientCertificate: certPFX, ClientCertificatePassword: "secret"},
+		},
+		"pkcs12 wrong password": {
+			config:    providerConfig{ClientCertificate: certPFX, ClientCertificatePassword: "wrong"},
+			attribute: "client_certificate_password",
+		},
+		"pkcs12 without password": {
+			config:    providerConfig{ClientCertificate: certPFX},
+			attribute: "client_certificate_password",
+		},
+		"inline not base64": {
+			config:    providerConfig{ClientCertificate: "not base64!"},
+			attribute: "client_certificate",
+		},
+		"inline not a certificate": {
+			config:    providerConfig{ClientCertificate: base64.StdEncoding.EncodeToString([]byte("garbage"))},
+			attribute: "client_certificate",
+		},
+		"missing file": {
+			config:    providerConfig{ClientCertificatePath: filepath.Join(t.TempDir(), "missing.pfx")},
+			attribute: "client_certificate_path",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			certificate, err := tc.config.loadClientCertificate()
+			if tc.attribute == "" {
+				if err != nil {
+					t.Fatalf("loading certificate: %s", err)
+				}
+				if len(certificate.certs) != 1 || certificate.key == nil {
+					t.Fatalf("expected one certificate and a private key, got %d certificates", len(certificate.certs))
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("expected an error for %s", tc.attribute)
+			}
+			if err.attribute != tc.attribute {
+				t.Fatalf("expected the error to name %q, got %q", tc.attribute, err.attribute)
+			}
+		})
+	}
+}
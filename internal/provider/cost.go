@@ -0,0 +1,146 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	subscriptionSettings "github.com/ekristen/terraform-provider-azurex/internal/azure/subscriptions"
+)
+
+// costScopePattern matches the scopes Cost Management exports, views and
+// queries accept: subscriptions, resource groups, management groups and
+// billing accounts with their billing profiles, invoice sections,
+// departments and enrollment accounts.
+var costScopePattern = regexp.MustCompile(`(?i)^/(subscriptions/[^/]+(/resourceGroups/[^/]+)?|providers/Microsoft\.Management/managementGroups/[^/]+|providers/Microsoft\.Billing/billingAccounts/[^/]+(/billingProfiles/[^/]+(/invoiceSections/[^/]+)?|/departments/[^/]+|/enrollmentAccounts/[^/]+)?)/?$`)
+
+// costScopeDescription documents costScopePattern in schema descriptions.
+const costScopeDescription = "a subscription (`/subscriptions/{subscriptionId}`), a resource group (`/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}`), " +
+	"a management group (`/providers/Microsoft.Management/managementGroups/{managementGroupId}`) or a billing scope such as " +
+	"`/providers/Microsoft.Billing/billingAccounts/{billingAccountId}/billingProfiles/{billingProfileId}`"
+
+// costResourceID returns the ID of a Cost Management resource of kind, e.g.
+// exports, named name at scope.
+func costResourceID(scope string, kind string, name string) string {
+	return fmt.Sprintf("%s/providers/Microsoft.CostManagement/%s/%s", strings.TrimSuffix(scope, "/"), kind, name)
+}
+
+// parseCostResourceID splits the ID of a Cost Management resource of kind
+// into its scope and name.
+func parseCostResourceID(id string, kind string) (scope string, name string, err error) {
+	separator := "/providers/Microsoft.CostManagement/" + kind + "/"
+	i := strings.LastIndex(strings.ToLower(id), strings.ToLower(separator))
+	if i <= 0 || strings.Contains(id[i+len(separator):], "/") || id[i+len(separator):] == "" {
+		return "", "", fmt.Errorf("expected {scope}%s{name}, got %q", separator, id)
+	}
+	scope, name = id[:i], id[i+len(separator):]
+	if !costScopePattern.MatchString(scope) {
+		return "", "", fmt.Errorf("unsupported scope %q", scope)
+	}
+	return scope, name, nil
+}
+
+// parseCostTime parses an RFC 3339 timestamp set in the configuration.
+func parseCostTime(value types.String) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value.ValueString())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected an RFC 3339 timestamp such as 2030-01-01T00:00:00Z, got %q", value.ValueString())
+	}
+	return t, nil
+}
+
+// costTimePeriod parses the from and to of a time_period attribute, which
+// must not end before it starts.
+func costTimePeriod(ctx context.Context, timePeriod types.Object) (from time.Time, to time.Time, diags diag.Diagnostics) {
+	var model costExportTimePeriodModel
+	diags.Append(timePeriod.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return from, to, diags
+	}
+
+	var err error
+	if from, err = parseCostTime(model.From); err != nil {
+		diags.AddAttributeError(path.Root("time_period").AtName("from"), "Invalid time period", err.Error())
+	}
+	if to, err = parseCostTime(model.To); err != nil {
+		diags.AddAttributeError(path.Root("time_period").AtName("to"), "Invalid time period", err.Error())
+	}
+	if !diags.HasError() && to.Before(from) {
+		diags.AddAttributeError(path.Root("time_period").AtName("to"), "Invalid time period",
+			fmt.Sprintf("The end of the time period, %s, is before its start, %s.", model.To.ValueString(), model.From.ValueString()))
+	}
+	return from, to, diags
+}
+
+// validateCostTimePeriod checks the time_period of a configuration against
+// its timeframe: a Custom timeframe requires a time period, which is only
+// valid with Custom and must not end before it starts.
+func validateCostTimePeriod(ctx context.Context, timeframe types.String, timePeriod types.Object) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if timeframe.IsUnknown() || timePeriod.IsUnknown() {
+		return diags
+	}
+
+	custom := timeframe.ValueString() == string(subscriptionSettings.TimeframeTypeCustom)
+	if custom && timePeriod.IsNull() {
+		diags.AddAttributeError(path.Root("time_period"), "Missing time period",
+			"The time_period attribute must be set when timeframe is Custom.")
+	}
+	if !custom && !timePeriod.IsNull() {
+		diags.AddAttributeError(path.Root("time_period"), "Unexpected time period",
+			"The time_period attribute can only be set when timeframe is Custom.")
+	}
+
+	if custom && !timePeriod.IsNull() {
+		attributes := timePeriod.Attributes()
+		if !attributes["from"].IsUnknown() && !attributes["to"].IsUnknown() {
+			_, _, d := costTimePeriod(ctx, timePeriod)
+			diags.Append(d...)
+		}
+	}
+	return diags
+}
+
+// costTimeValue returns t as an RFC 3339 string, keeping current when it
+// already describes the same instant so a differently formatted value in the
+// configuration is not reported as drift.
+func costTimeValue(current types.String, t *time.Time) types.String {
+	if t == nil || t.IsZero() {
+		return types.StringNull()
+	}
+	if !current.IsNull() && !current.IsUnknown() {
+		if parsed, err := time.Parse(time.RFC3339, current.ValueString()); err == nil && parsed.Equal(*t) {
+			return current
+		}
+	}
+	return types.StringValue(t.UTC().Format(time.RFC3339))
+}
+
+// stringValueOrNull returns s, or null when s is empty.
+func stringValueOrNull(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
+
+// enumValues converts the Possible*Values of a Cost Management enum for use
+// with stringvalidator.OneOf.
+func enumValues[T ~string](values []T) []string {
+	s := make([]string, 0, len(values))
+	for _, v := range values {
+		s = append(s, string(v))
+	}
+	return s
+}
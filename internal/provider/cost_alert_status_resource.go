@@ -0,0 +1,211 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	subscriptionSettings "github.com/ekristen/terraform-provider-azurex/internal/azure/subscriptions"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &CostAlertStatusResource{}
+var _ resource.ResourceWithImportState = &CostAlertStatusResource{}
+
+func NewCostAlertStatusResource() resource.Resource {
+	return &CostAlertStatusResource{}
+}
+
+// CostAlertStatusResource defines the resource implementation.
+type CostAlertStatusResource struct {
+	AlertsClient *subscriptionSettings.AlertsClient
+}
+
+// CostAlertStatusResourceModel describes the resource data model.
+type CostAlertStatusResourceModel struct {
+	ID     types.String `tfsdk:"id"`
+	Scope  types.String `tfsdk:"scope"`
+	Name   types.String `tfsdk:"name"`
+	Status types.String `tfsdk:"status"`
+}
+
+func (r *CostAlertStatusResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cost_alert_status"
+}
+
+func (r *CostAlertStatusResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Status of an existing Cost Management alert, used to dismiss or resolve alerts. " +
+			"Destroying the resource leaves the status of the alert unchanged.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the alert",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"scope": schema.StringAttribute{
+				MarkdownDescription: "Scope of the alert, " + costScopeDescription + ". Changing this forces a new resource to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(costScopePattern, "must be a subscription, resource group, management group or billing scope"),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the alert, e.g. from the `azurex_cost_alerts` data source. Changing this forces a new resource to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Status of the alert, `Active`, `Dismissed` or `Resolved`",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(subscriptionSettings.AlertStatusActive),
+						string(subscriptionSettings.AlertStatusDismissed),
+						string(subscriptionSettings.AlertStatusResolved),
+					),
+				},
+			},
+		},
+	}
+}
+
+func (r *CostAlertStatusResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(AzurexContext)
+	if !ok {
+		resp.Diagnostics.AddError("unable to obtain provider data", "provider data not available")
+		return
+	}
+
+	alertsClient, err := subscriptionSettings.NewAlertsClient(data.IdentityCreds, data.ClientOptions)
+	if err != nil {
+		resp.Diagnostics.AddError("unable to configure alerts client", fmt.Sprintf("got: %s", err.Error()))
+		return
+	}
+	r.AlertsClient = alertsClient
+}
+
+func (r *CostAlertStatusResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *CostAlertStatusResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "creating cost alert status resource")
+
+	scope := strings.TrimSuffix(data.Scope.ValueString(), "/")
+	alert, err := r.AlertsClient.Dismiss(ctx, scope, data.Name.ValueString(), subscriptionSettings.AlertStatus(data.Status.ValueString()))
+	if err != nil {
+		resp.Diagnostics.Append(responseErrorDiagnostic("Error setting cost alert status", err))
+		return
+	}
+
+	data.ID = types.StringValue(costResourceID(scope, "alerts", data.Name.ValueString()))
+	data.Status = types.StringValue(string(alert.Properties.Status))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CostAlertStatusResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *CostAlertStatusResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	scope := strings.TrimSuffix(data.Scope.ValueString(), "/")
+	alert, err := r.AlertsClient.Get(ctx, scope, data.Name.ValueString())
+	if subscriptionSettings.IsNotFound(err) {
+		tflog.Debug(ctx, fmt.Sprintf("cost alert %s no longer exists, removing from state", data.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(responseErrorDiagnostic("Error reading cost alert", err))
+		return
+	}
+
+	data.Status = types.StringValue(string(alert.Properties.Status))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CostAlertStatusResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *CostAlertStatusResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updating cost alert status resource")
+
+	scope := strings.TrimSuffix(data.Scope.ValueString(), "/")
+	alert, err := r.AlertsClient.Dismiss(ctx, scope, data.Name.ValueString(), subscriptionSettings.AlertStatus(data.Status.ValueString()))
+	if err != nil {
+		resp.Diagnostics.Append(responseErrorDiagnostic("Error setting cost alert status", err))
+		return
+	}
+
+	data.Status = types.StringValue(string(alert.Properties.Status))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CostAlertStatusResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *CostAlertStatusResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Alerts are owned by Cost Management, so only the resource is forgotten
+	tflog.Debug(ctx, fmt.Sprintf("leaving cost alert %s as %s", data.ID.ValueString(), data.Status.ValueString()))
+}
+
+// ImportState accepts the alert ID, e.g.
+// /subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.CostManagement/alerts/example.
+func (r *CostAlertStatusResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	scope, name, err := parseCostResourceID(req.ID, "alerts")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("scope"), scope)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
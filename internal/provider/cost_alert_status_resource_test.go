@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccCostAlertStatusResource(t *testing.T) {
+	skipWithoutTerraform(t)
+	fake := newFakeARM(t)
+	id := fakeSubscriptionScope + "/providers/Microsoft.CostManagement/alerts/budget-80"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fake.providerFactories(),
+		CheckDestroy: func(*terraform.State) error {
+			alert := fake.getCostResourceAt(id)
+			if status := alert["properties"].(map[string]any)["status"]; status != "Active" {
+				return fmt.Errorf("expected alert %s to be left as Active, got %v", id, status)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + testAccCostAlertStatusResourceConfig("budget-80", "Dismissed"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("azurex_cost_alert_status.test", "id", id),
+					resource.TestCheckResourceAttr("azurex_cost_alert_status.test", "status", "Dismissed"),
+				),
+			},
+			{
+				ResourceName:      "azurex_cost_alert_status.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: fake.providerConfig() + testAccCostAlertStatusResourceConfig("budget-80", "Active"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("azurex_cost_alert_status.test", "status", "Active"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCostAlertStatusResource_notFound(t *testing.T) {
+	skipWithoutTerraform(t)
+	fake := newFakeARM(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fake.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config:      fake.providerConfig() + testAccCostAlertStatusResourceConfig("missing", "Dismissed"),
+				ExpectError: regexp.MustCompile(`Alert 'missing' was not found`),
+			},
+		},
+	})
+}
+
+func testAccCostAlertStatusResourceConfig(name string, status string) string {
+	return fmt.Sprintf(`
+resource "azurex_cost_alert_status" "test" {
+  scope  = %[1]q
+  name   = %[2]q
+  status = %[3]q
+}
+`, fakeSubscriptionScope, name, status)
+}
@@ -0,0 +1,305 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	subscriptionSettings "github.com/ekristen/terraform-provider-azurex/internal/azure/subscriptions"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CostAlertsDataSource{}
+
+func NewCostAlertsDataSource() datasource.DataSource {
+	return &CostAlertsDataSource{}
+}
+
+// CostAlertsDataSource defines the data source implementation.
+type CostAlertsDataSource struct {
+	AlertsClient *subscriptionSettings.AlertsClient
+}
+
+// CostAlertsDataSourceModel describes the data source data model.
+type CostAlertsDataSourceModel struct {
+	Scope      types.String `tfsdk:"scope"`
+	Statuses   types.Set    `tfsdk:"statuses"`
+	Categories types.Set    `tfsdk:"categories"`
+	Types      types.Set    `tfsdk:"types"`
+	Alerts     types.List   `tfsdk:"alerts"`
+}
+
+// costAlertModel is a single entry of alerts.
+type costAlertModel struct {
+	ID                         types.String  `tfsdk:"id"`
+	Name                       types.String  `tfsdk:"name"`
+	Type                       types.String  `tfsdk:"type"`
+	Category                   types.String  `tfsdk:"category"`
+	Criteria                   types.String  `tfsdk:"criteria"`
+	Source                     types.String  `tfsdk:"source"`
+	Status                     types.String  `tfsdk:"status"`
+	Description                types.String  `tfsdk:"description"`
+	Amount                     types.Float64 `tfsdk:"amount"`
+	CurrentSpend               types.Float64 `tfsdk:"current_spend"`
+	Threshold                  types.Float64 `tfsdk:"threshold"`
+	Operator                   types.String  `tfsdk:"operator"`
+	Unit                       types.String  `tfsdk:"unit"`
+	TimeGrainType              types.String  `tfsdk:"time_grain_type"`
+	CostEntityID               types.String  `tfsdk:"cost_entity_id"`
+	ContactEmails              types.List    `tfsdk:"contact_emails"`
+	CreationTime               types.String  `tfsdk:"creation_time"`
+	ModificationTime           types.String  `tfsdk:"modification_time"`
+	CloseTime                  types.String  `tfsdk:"close_time"`
+	StatusModificationTime     types.String  `tfsdk:"status_modification_time"`
+	StatusModificationUserName types.String  `tfsdk:"status_modification_user_name"`
+}
+
+var costAlertAttrTypes = map[string]attr.Type{
+	"id":                            types.StringType,
+	"name":                          types.StringType,
+	"type":                          types.StringType,
+	"category":                      types.StringType,
+	"criteria":                      types.StringType,
+	"source":                        types.StringType,
+	"status":                        types.StringType,
+	"description":                   types.StringType,
+	"amount":                        types.Float64Type,
+	"current_spend":                 types.Float64Type,
+	"threshold":                     types.Float64Type,
+	"operator":                      types.StringType,
+	"unit":                          types.StringType,
+	"time_grain_type":               types.StringType,
+	"cost_entity_id":                types.StringType,
+	"contact_emails":                types.ListType{ElemType: types.StringType},
+	"creation_time":                 types.StringType,
+	"modification_time":             types.StringType,
+	"close_time":                    types.StringType,
+	"status_modification_time":      types.StringType,
+	"status_modification_user_name": types.StringType,
+}
+
+func (d *CostAlertsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cost_alerts"
+}
+
+func (d *CostAlertsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	computedString := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{MarkdownDescription: description, Computed: true}
+	}
+	computedFloat := func(description string) schema.Float64Attribute {
+		return schema.Float64Attribute{MarkdownDescription: description, Computed: true}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the Cost Management alerts of a scope, such as budget, credit, quota and invoice alerts",
+
+		Attributes: map[string]schema.Attribute{
+			"scope": schema.StringAttribute{
+				MarkdownDescription: "Scope to list the alerts of, " + costScopeDescription,
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(costScopePattern, "must be a subscription, resource group, management group or billing scope"),
+				},
+			},
+			"statuses": schema.SetAttribute{
+				MarkdownDescription: "Only list alerts with one of these statuses, `Active`, `Dismissed`, `None`, `Overridden` or `Resolved`. Defaults to `[\"Active\"]`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(enumValues(subscriptionSettings.PossibleAlertStatusValues())...)),
+				},
+			},
+			"categories": schema.SetAttribute{
+				MarkdownDescription: "Only list alerts of these categories, `Billing`, `Cost`, `System` or `Usage`",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(enumValues(subscriptionSettings.PossibleAlertCategoryValues())...)),
+				},
+			},
+			"types": schema.SetAttribute{
+				MarkdownDescription: "Only list alerts of these types, e.g. `Budget`, `BudgetForecast` or `Quota`",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(enumValues(subscriptionSettings.PossibleAlertTypeValues())...)),
+				},
+			},
+			"alerts": schema.ListNestedAttribute{
+				MarkdownDescription: "Alerts matching the filters",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":              computedString("ID of the alert"),
+						"name":            computedString("Name of the alert, used as `name` of `azurex_cost_alert_status`"),
+						"type":            computedString("Type of the alert, e.g. `Budget`"),
+						"category":        computedString("Category of the alert, e.g. `Cost`"),
+						"criteria":        computedString("Criteria that triggered the alert, e.g. `CostThresholdExceeded`"),
+						"source":          computedString("Source of the alert, `Preset` or `User`"),
+						"status":          computedString("Status of the alert, e.g. `Active`"),
+						"description":     computedString("Description of the alert"),
+						"amount":          computedFloat("Budget amount the alert compares the spend with"),
+						"current_spend":   computedFloat("Spend when the alert was triggered"),
+						"threshold":       computedFloat("Threshold that triggered the alert, as a fraction of `amount`"),
+						"operator":        computedString("Operator comparing `current_spend` with `amount`, e.g. `GreaterThan`"),
+						"unit":            computedString("Currency of `amount` and `current_spend`"),
+						"time_grain_type": computedString("Period the budget resets at, e.g. `Monthly`"),
+						"cost_entity_id":  computedString("ID of the budget the alert is for"),
+						"contact_emails": schema.ListAttribute{
+							MarkdownDescription: "Email addresses notified of the alert",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"creation_time":                 computedString("Time the alert was created"),
+						"modification_time":             computedString("Time the alert was last modified"),
+						"close_time":                    computedString("Time the alert was closed"),
+						"status_modification_time":      computedString("Time the status of the alert was last modified"),
+						"status_modification_user_name": computedString("User who last modified the status of the alert"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *CostAlertsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(AzurexContext)
+	if !ok {
+		resp.Diagnostics.AddError("unable to obtain provider data", "provider data not available")
+		return
+	}
+
+	alertsClient, err := subscriptionSettings.NewAlertsClient(data.IdentityCreds, data.ClientOptions)
+	if err != nil {
+		resp.Diagnostics.AddError("unable to configure alerts client", fmt.Sprintf("got: %s", err.Error()))
+		return
+	}
+	d.AlertsClient = alertsClient
+}
+
+func (d *CostAlertsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CostAlertsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	statuses := []string{string(subscriptionSettings.AlertStatusActive)}
+	if !data.Statuses.IsNull() {
+		resp.Diagnostics.Append(data.Statuses.ElementsAs(ctx, &statuses, false)...)
+	}
+	var categories, alertTypes []string
+	resp.Diagnostics.Append(data.Categories.ElementsAs(ctx, &categories, false)...)
+	resp.Diagnostics.Append(data.Types.ElementsAs(ctx, &alertTypes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	scope := strings.TrimSuffix(data.Scope.ValueString(), "/")
+	alerts, err := d.AlertsClient.List(ctx, scope)
+	if err != nil {
+		resp.Diagnostics.Append(responseErrorDiagnostic("Error listing cost alerts", err))
+		return
+	}
+
+	models := make([]costAlertModel, 0)
+	for _, alert := range alerts {
+		var definition subscriptionSettings.AlertPropertiesDefinition
+		if alert.Properties.Definition != nil {
+			definition = *alert.Properties.Definition
+		}
+		if !slices.Contains(statuses, string(alert.Properties.Status)) ||
+			(categories != nil && !slices.Contains(categories, string(definition.Category))) ||
+			(alertTypes != nil && !slices.Contains(alertTypes, string(definition.Type))) {
+			continue
+		}
+
+		model, diags := newCostAlertModel(ctx, alert)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		models = append(models, model)
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("read %d of %d cost alerts for %s", len(models), len(alerts), scope))
+
+	alertsValue, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: costAlertAttrTypes}, models)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Alerts = alertsValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func newCostAlertModel(ctx context.Context, alert subscriptionSettings.Alert) (costAlertModel, diag.Diagnostics) {
+	properties := alert.Properties
+	model := costAlertModel{
+		ID:                         types.StringValue(alert.ID),
+		Name:                       types.StringValue(alert.Name),
+		Source:                     stringValueOrNull(string(properties.Source)),
+		Status:                     stringValueOrNull(string(properties.Status)),
+		Description:                stringValueOrNull(properties.Description),
+		CostEntityID:               stringValueOrNull(properties.CostEntityID),
+		CreationTime:               stringValueOrNull(properties.CreationTime),
+		ModificationTime:           stringValueOrNull(properties.ModificationTime),
+		CloseTime:                  stringValueOrNull(properties.CloseTime),
+		StatusModificationTime:     stringValueOrNull(properties.StatusModificationTime),
+		StatusModificationUserName: stringValueOrNull(properties.StatusModificationUserName),
+		Type:                       types.StringNull(),
+		Category:                   types.StringNull(),
+		Criteria:                   types.StringNull(),
+		Amount:                     types.Float64Null(),
+		CurrentSpend:               types.Float64Null(),
+		Threshold:                  types.Float64Null(),
+		Operator:                   types.StringNull(),
+		Unit:                       types.StringNull(),
+		TimeGrainType:              types.StringNull(),
+		ContactEmails:              types.ListNull(types.StringType),
+	}
+
+	if definition := properties.Definition; definition != nil {
+		model.Type = stringValueOrNull(string(definition.Type))
+		model.Category = stringValueOrNull(string(definition.Category))
+		model.Criteria = stringValueOrNull(string(definition.Criteria))
+	}
+
+	var diags diag.Diagnostics
+	if details := properties.Details; details != nil {
+		model.Amount = types.Float64Value(details.Amount)
+		model.CurrentSpend = types.Float64Value(details.CurrentSpend)
+		model.Threshold = types.Float64Value(details.Threshold)
+		model.Operator = stringValueOrNull(string(details.Operator))
+		model.Unit = stringValueOrNull(details.Unit)
+		model.TimeGrainType = stringValueOrNull(string(details.TimeGrainType))
+		if details.ContactEmails != nil {
+			model.ContactEmails, diags = types.ListValueFrom(ctx, types.StringType, details.ContactEmails)
+		}
+	}
+	return model, diags
+}
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCostAlertsDataSource(t *testing.T) {
+	skipWithoutTerraform(t)
+	fake := newFakeARM(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fake.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+data "azurex_cost_alerts" "test" {
+  scope = "/subscriptions/00000000-0000-0000-0000-000000000000"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.azurex_cost_alerts.test", "alerts.#", "2"),
+					resource.TestCheckResourceAttr("data.azurex_cost_alerts.test", "alerts.0.name", "budget-80"),
+					resource.TestCheckResourceAttr("data.azurex_cost_alerts.test", "alerts.0.type", "Budget"),
+					resource.TestCheckResourceAttr("data.azurex_cost_alerts.test", "alerts.0.criteria", "CostThresholdExceeded"),
+					resource.TestCheckResourceAttr("data.azurex_cost_alerts.test", "alerts.0.current_spend", "85.5"),
+					resource.TestCheckResourceAttr("data.azurex_cost_alerts.test", "alerts.0.contact_emails.0", "finops@example.com"),
+					resource.TestCheckResourceAttr("data.azurex_cost_alerts.test", "alerts.1.name", "budget-forecast-100"),
+					resource.TestCheckNoResourceAttr("data.azurex_cost_alerts.test", "alerts.1.amount"),
+				),
+			},
+			{
+				Config: fake.providerConfig() + `
+data "azurex_cost_alerts" "test" {
+  scope    = "/subscriptions/00000000-0000-0000-0000-000000000000"
+  statuses = ["Active", "Dismissed"]
+  types    = ["Budget", "Quota"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.azurex_cost_alerts.test", "alerts.#", "2"),
+					resource.TestCheckResourceAttr("data.azurex_cost_alerts.test", "alerts.1.name", "quota"),
+					resource.TestCheckResourceAttr("data.azurex_cost_alerts.test", "alerts.1.status", "Dismissed"),
+					resource.TestCheckResourceAttr("data.azurex_cost_alerts.test", "alerts.1.category", "Usage"),
+				),
+			},
+			{
+				Config: fake.providerConfig() + `
+resource "azurex_cost_alert_status" "test" {
+  scope  = "/subscriptions/00000000-0000-0000-0000-000000000000"
+  name   = "budget-forecast-100"
+  status = "Resolved"
+}
+
+data "azurex_cost_alerts" "test" {
+  scope      = azurex_cost_alert_status.test.scope
+  categories = ["Cost"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.azurex_cost_alerts.test", "alerts.#", "1"),
+					resource.TestCheckResourceAttr("data.azurex_cost_alerts.test", "alerts.0.name", "budget-80"),
+				),
+			},
+		},
+	})
+}
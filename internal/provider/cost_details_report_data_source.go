@@ -0,0 +1,236 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	subscriptionSettings "github.com/ekristen/terraform-provider-azurex/internal/azure/subscriptions"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CostDetailsReportDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &CostDetailsReportDataSource{}
+
+func NewCostDetailsReportDataSource() datasource.DataSource {
+	return &CostDetailsReportDataSource{}
+}
+
+// CostDetailsReportDataSource defines the data source implementation.
+type CostDetailsReportDataSource struct {
+	CostDetailsClient *subscriptionSettings.CostDetailsClient
+}
+
+// CostDetailsReportDataSourceModel describes the data source data model.
+type CostDetailsReportDataSourceModel struct {
+	Scope         types.String `tfsdk:"scope"`
+	Metric        types.String `tfsdk:"metric"`
+	TimePeriod    types.Object `tfsdk:"time_period"`
+	BillingPeriod types.String `tfsdk:"billing_period"`
+	InvoiceID     types.String `tfsdk:"invoice_id"`
+	Status        types.String `tfsdk:"status"`
+	DataFormat    types.String `tfsdk:"data_format"`
+	ByteCount     types.Int64  `tfsdk:"byte_count"`
+	BlobURLs      types.List   `tfsdk:"blob_urls"`
+	ValidTill     types.String `tfsdk:"valid_till"`
+}
+
+func (d *CostDetailsReportDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cost_details_report"
+}
+
+func (d *CostDetailsReportDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Generates a cost details report of a scope and returns the URLs of its blobs. " +
+			"Every read generates a new report, which can take several minutes.",
+
+		Attributes: map[string]schema.Attribute{
+			"scope": schema.StringAttribute{
+				MarkdownDescription: "Scope to report the cost details of, " + costScopeDescription,
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(costScopePattern, "must be a subscription, resource group, management group or billing scope"),
+				},
+			},
+			"metric": schema.StringAttribute{
+				MarkdownDescription: "Costs to report, `ActualCost` or `AmortizedCost`. Defaults to `ActualCost`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(enumValues(subscriptionSettings.PossibleGenerateDetailedCostReportMetricTypeValues())...),
+				},
+			},
+			"time_period": schema.SingleNestedAttribute{
+				MarkdownDescription: "Date range to report, at most a month. Only the dates of the timestamps are used. " +
+					"Conflicts with `billing_period` and `invoice_id`, the current month is reported when none is set.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"from": schema.StringAttribute{
+						MarkdownDescription: "Start of the date range, as an RFC 3339 timestamp",
+						Required:            true,
+					},
+					"to": schema.StringAttribute{
+						MarkdownDescription: "End of the date range, as an RFC 3339 timestamp",
+						Required:            true,
+					},
+				},
+			},
+			"billing_period": schema.StringAttribute{
+				MarkdownDescription: "Billing period to report, e.g. `203001`. Only supported for Enterprise Agreement scopes.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^\d{4}(0[1-9]|1[0-2])$`), "must be a year and month such as 203001"),
+				},
+			},
+			"invoice_id": schema.StringAttribute{
+				MarkdownDescription: "Invoice to report. Only supported for pay-as-you-go and Microsoft Customer Agreement scopes.",
+				Optional:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Status of the report, `Completed` or `NoDataFound` when there are no costs to report",
+				Computed:            true,
+			},
+			"data_format": schema.StringAttribute{
+				MarkdownDescription: "Format of the blobs, e.g. `Csv`",
+				Computed:            true,
+			},
+			"byte_count": schema.Int64Attribute{
+				MarkdownDescription: "Total size of the blobs in bytes",
+				Computed:            true,
+			},
+			"blob_urls": schema.ListAttribute{
+				MarkdownDescription: "URLs to download the blobs of the report from, until `valid_till`. The URLs carry a SAS token.",
+				ElementType:         types.StringType,
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"valid_till": schema.StringAttribute{
+				MarkdownDescription: "Time the blob URLs expire at",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *CostDetailsReportDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data CostDetailsReportDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	periods := 0
+	for _, value := range []attr.Value{data.TimePeriod, data.BillingPeriod, data.InvoiceID} {
+		if !value.IsNull() && !value.IsUnknown() {
+			periods++
+		}
+	}
+	if periods > 1 {
+		resp.Diagnostics.AddError("Conflicting report periods",
+			"Only one of time_period, billing_period and invoice_id can be set.")
+	}
+}
+
+func (d *CostDetailsReportDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(AzurexContext)
+	if !ok {
+		resp.Diagnostics.AddError("unable to obtain provider data", "provider data not available")
+		return
+	}
+
+	costDetailsClient, err := subscriptionSettings.NewCostDetailsClient(data.IdentityCreds, data.ClientOptions)
+	if err != nil {
+		resp.Diagnostics.AddError("unable to configure cost details client", fmt.Sprintf("got: %s", err.Error()))
+		return
+	}
+	d.CostDetailsClient = costDetailsClient
+}
+
+func (d *CostDetailsReportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CostDetailsReportDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	report, diags := data.report(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	scope := strings.TrimSuffix(data.Scope.ValueString(), "/")
+	tflog.Debug(ctx, fmt.Sprintf("generating cost details report for %s", scope))
+
+	result, err := d.CostDetailsClient.Generate(ctx, scope, report)
+	if err != nil {
+		resp.Diagnostics.Append(responseErrorDiagnostic("Error generating cost details report", err))
+		return
+	}
+
+	data.Status = types.StringValue(string(result.Status))
+	data.DataFormat = types.StringNull()
+	data.ByteCount = types.Int64Value(0)
+	data.ValidTill = costTimeValue(types.StringNull(), result.ValidTill)
+
+	blobURLs := make([]string, 0)
+	if manifest := result.Manifest; manifest != nil {
+		data.DataFormat = stringValueOrNull(string(manifest.DataFormat))
+		data.ByteCount = types.Int64Value(manifest.ByteCount)
+		for _, blob := range manifest.Blobs {
+			blobURLs = append(blobURLs, blob.BlobLink)
+		}
+	}
+	data.BlobURLs, diags = types.ListValueFrom(ctx, types.StringType, blobURLs)
+	resp.Diagnostics.Append(diags...)
+
+	tflog.Trace(ctx, fmt.Sprintf("cost details report for %s is %s with %d blobs", scope, result.Status, len(blobURLs)))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// report builds the report request described by the model.
+func (m *CostDetailsReportDataSourceModel) report(ctx context.Context) (subscriptionSettings.GenerateCostDetailsReportRequestDefinition, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	report := subscriptionSettings.GenerateCostDetailsReportRequestDefinition{
+		Metric:        subscriptionSettings.CostDetailsMetricTypeActualCostCostDetailsMetricType,
+		BillingPeriod: m.BillingPeriod.ValueString(),
+		InvoiceID:     m.InvoiceID.ValueString(),
+	}
+	if !m.Metric.IsNull() {
+		report.Metric = subscriptionSettings.CostDetailsMetricType(m.Metric.ValueString())
+	}
+
+	if !m.TimePeriod.IsNull() {
+		from, to, d := costTimePeriod(ctx, m.TimePeriod)
+		diags.Append(d...)
+		report.TimePeriod = &subscriptionSettings.CostDetailsTimePeriod{
+			Start: from.UTC().Format(time.DateOnly),
+			End:   to.UTC().Format(time.DateOnly),
+		}
+	}
+
+	return report, diags
+}
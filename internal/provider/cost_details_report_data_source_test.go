@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCostDetailsReportDataSource(t *testing.T) {
+	skipWithoutTerraform(t)
+	fake := newFakeARM(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fake.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+data "azurex_cost_details_report" "test" {
+  scope  = "/subscriptions/00000000-0000-0000-0000-000000000000"
+  metric = "AmortizedCost"
+
+  time_period = {
+    from = "2030-01-01T00:00:00Z"
+    to   = "2030-01-31T00:00:00Z"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.azurex_cost_details_report.test", "status", "Completed"),
+					resource.TestCheckResourceAttr("data.azurex_cost_details_report.test", "data_format", "Csv"),
+					resource.TestCheckResourceAttr("data.azurex_cost_details_report.test", "byte_count", "300"),
+					resource.TestCheckResourceAttr("data.azurex_cost_details_report.test", "blob_urls.#", "2"),
+					resource.TestMatchResourceAttr("data.azurex_cost_details_report.test", "blob_urls.1", regexp.MustCompile(`/part1\.csv\?sig=fake$`)),
+					resource.TestCheckResourceAttr("data.azurex_cost_details_report.test", "valid_till", "2030-01-02T00:00:00Z"),
+				),
+			},
+			{
+				Config: fake.providerConfig() + `
+data "azurex_cost_details_report" "test" {
+  scope = "/subscriptions/00000000-0000-0000-0000-000000000000"
+
+  time_period = {
+    from = "2030-03-01T00:00:00Z"
+    to   = "2030-03-31T00:00:00Z"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.azurex_cost_details_report.test", "status", "NoDataFound"),
+					resource.TestCheckResourceAttr("data.azurex_cost_details_report.test", "blob_urls.#", "0"),
+					resource.TestCheckNoResourceAttr("data.azurex_cost_details_report.test", "valid_till"),
+				),
+			},
+			{
+				Config: fake.providerConfig() + `
+data "azurex_cost_details_report" "test" {
+  scope          = "/subscriptions/00000000-0000-0000-0000-000000000000"
+  billing_period = "203001"
+
+  time_period = {
+    from = "2030-01-01T00:00:00Z"
+    to   = "2030-01-31T00:00:00Z"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`Only one of time_period, billing_period and invoice_id can be set`),
+			},
+		},
+	})
+}
@@ -0,0 +1,652 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	subscriptionSettings "github.com/ekristen/terraform-provider-azurex/internal/azure/subscriptions"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &CostExportResource{}
+var _ resource.ResourceWithImportState = &CostExportResource{}
+var _ resource.ResourceWithValidateConfig = &CostExportResource{}
+
+func NewCostExportResource() resource.Resource {
+	return &CostExportResource{}
+}
+
+// CostExportResource defines the resource implementation.
+type CostExportResource struct {
+	ExportsClient *subscriptionSettings.ExportsClient
+}
+
+// CostExportResourceModel describes the resource data model.
+type CostExportResourceModel struct {
+	ID                  types.String `tfsdk:"id"`
+	Name                types.String `tfsdk:"name"`
+	Scope               types.String `tfsdk:"scope"`
+	Type                types.String `tfsdk:"type"`
+	Timeframe           types.String `tfsdk:"timeframe"`
+	TimePeriod          types.Object `tfsdk:"time_period"`
+	Granularity         types.String `tfsdk:"granularity"`
+	Columns             types.List   `tfsdk:"columns"`
+	Format              types.String `tfsdk:"format"`
+	PartitionData       types.Bool   `tfsdk:"partition_data"`
+	StorageAccountID    types.String `tfsdk:"storage_account_id"`
+	Container           types.String `tfsdk:"container"`
+	RootFolderPath      types.String `tfsdk:"root_folder_path"`
+	Schedule            types.Object `tfsdk:"schedule"`
+	RunNow              types.Bool   `tfsdk:"run_now"`
+	NextRunTimeEstimate types.String `tfsdk:"next_run_time_estimate"`
+	RunHistory          types.List   `tfsdk:"run_history"`
+}
+
+// costExportTimePeriodModel describes the time_period attribute.
+type costExportTimePeriodModel struct {
+	From types.String `tfsdk:"from"`
+	To   types.String `tfsdk:"to"`
+}
+
+var costExportTimePeriodAttrTypes = map[string]attr.Type{
+	"from": types.StringType,
+	"to":   types.StringType,
+}
+
+// costExportScheduleModel describes the schedule attribute.
+type costExportScheduleModel struct {
+	Recurrence types.String `tfsdk:"recurrence"`
+	From       types.String `tfsdk:"from"`
+	To         types.String `tfsdk:"to"`
+	Active     types.Bool   `tfsdk:"active"`
+}
+
+var costExportScheduleAttrTypes = map[string]attr.Type{
+	"recurrence": types.StringType,
+	"from":       types.StringType,
+	"to":         types.StringType,
+	"active":     types.BoolType,
+}
+
+// costExportRunModel is a single entry of run_history.
+type costExportRunModel struct {
+	ExecutionType       types.String `tfsdk:"execution_type"`
+	Status              types.String `tfsdk:"status"`
+	SubmittedBy         types.String `tfsdk:"submitted_by"`
+	SubmittedTime       types.String `tfsdk:"submitted_time"`
+	ProcessingStartTime types.String `tfsdk:"processing_start_time"`
+	ProcessingEndTime   types.String `tfsdk:"processing_end_time"`
+	FileName            types.String `tfsdk:"file_name"`
+	Error               types.String `tfsdk:"error"`
+}
+
+var costExportRunAttrTypes = map[string]attr.Type{
+	"execution_type":        types.StringType,
+	"status":                types.StringType,
+	"submitted_by":          types.StringType,
+	"submitted_time":        types.StringType,
+	"processing_start_time": types.StringType,
+	"processing_end_time":   types.StringType,
+	"file_name":             types.StringType,
+	"error":                 types.StringType,
+}
+
+func (r *CostExportResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cost_export"
+}
+
+func (r *CostExportResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Cost Management export, delivers cost and usage data to a storage container once or on a schedule",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the export",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the export. Changing this forces a new resource to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope": schema.StringAttribute{
+				MarkdownDescription: "Scope to export the costs of, " + costScopeDescription + ". Changing this forces a new resource to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(costScopePattern, "must be a subscription, resource group, management group or billing scope"),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Type of the exported costs, one of `ActualCost`, `AmortizedCost` or `Usage`. Defaults to `ActualCost`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(string(subscriptionSettings.ExportTypeActualCost)),
+				Validators: []validator.String{
+					stringvalidator.OneOf(enumValues(subscriptionSettings.PossibleExportTypeValues())...),
+				},
+			},
+			"timeframe": schema.StringAttribute{
+				MarkdownDescription: "Time frame of the exported costs, e.g. `MonthToDate`. `Custom` requires `time_period`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(enumValues(subscriptionSettings.PossibleTimeframeTypeValues())...),
+				},
+			},
+			"time_period": schema.SingleNestedAttribute{
+				MarkdownDescription: "Date range of the exported costs, only valid with the `Custom` timeframe",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"from": schema.StringAttribute{
+						MarkdownDescription: "Start of the date range, as an RFC 3339 timestamp",
+						Required:            true,
+					},
+					"to": schema.StringAttribute{
+						MarkdownDescription: "End of the date range, as an RFC 3339 timestamp",
+						Required:            true,
+					},
+				},
+			},
+			"granularity": schema.StringAttribute{
+				MarkdownDescription: "Granularity of the exported rows, only `Daily` is supported. Costs are aggregated over the time frame when unset.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(enumValues(subscriptionSettings.PossibleGranularityTypeValues())...),
+				},
+			},
+			"columns": schema.ListAttribute{
+				MarkdownDescription: "Columns to export, every available column is exported when unset",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"format": schema.StringAttribute{
+				MarkdownDescription: "Format of the exported files, only `Csv` is supported. Defaults to `Csv`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(string(subscriptionSettings.FormatTypeCSV)),
+				Validators: []validator.String{
+					stringvalidator.OneOf(enumValues(subscriptionSettings.PossibleFormatTypeValues())...),
+				},
+			},
+			"partition_data": schema.BoolAttribute{
+				MarkdownDescription: "Split the exported data into several files described by a manifest. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"storage_account_id": schema.StringAttribute{
+				MarkdownDescription: "Resource ID of the storage account the export is delivered to",
+				Required:            true,
+			},
+			"container": schema.StringAttribute{
+				MarkdownDescription: "Name of the storage container the export is delivered to, created when missing",
+				Required:            true,
+			},
+			"root_folder_path": schema.StringAttribute{
+				MarkdownDescription: "Directory in the container the export is delivered to",
+				Optional:            true,
+			},
+			"schedule": schema.SingleNestedAttribute{
+				MarkdownDescription: "Schedule of the export, the export only runs on demand when unset",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"recurrence": schema.StringAttribute{
+						MarkdownDescription: "How often the export runs, one of `Daily`, `Weekly`, `Monthly` or `Annually`",
+						Required:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(enumValues(subscriptionSettings.PossibleRecurrenceTypeValues())...),
+						},
+					},
+					"from": schema.StringAttribute{
+						MarkdownDescription: "Start of the schedule, as an RFC 3339 timestamp in the future",
+						Required:            true,
+					},
+					"to": schema.StringAttribute{
+						MarkdownDescription: "End of the schedule, as an RFC 3339 timestamp",
+						Optional:            true,
+					},
+					"active": schema.BoolAttribute{
+						MarkdownDescription: "Set to `false` to pause the schedule. Defaults to `true`.",
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(true),
+					},
+				},
+			},
+			"run_now": schema.BoolAttribute{
+				MarkdownDescription: "Run the export when it is created, or when this changes from `false` to `true`, in addition to its schedule. Leaving it `true` does not run the export on later updates. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"next_run_time_estimate": schema.StringAttribute{
+				MarkdownDescription: "Estimated time of the next scheduled run",
+				Computed:            true,
+			},
+			"run_history": schema.ListNestedAttribute{
+				MarkdownDescription: "Most recent runs of the export",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"execution_type": schema.StringAttribute{
+							MarkdownDescription: "What triggered the run, `OnDemand` or `Scheduled`",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "Status of the run, e.g. `Completed` or `Failed`",
+							Computed:            true,
+						},
+						"submitted_by": schema.StringAttribute{
+							MarkdownDescription: "Who triggered the run, `System` for scheduled runs",
+							Computed:            true,
+						},
+						"submitted_time": schema.StringAttribute{
+							MarkdownDescription: "Time the run was queued",
+							Computed:            true,
+						},
+						"processing_start_time": schema.StringAttribute{
+							MarkdownDescription: "Time the run started",
+							Computed:            true,
+						},
+						"processing_end_time": schema.StringAttribute{
+							MarkdownDescription: "Time the run finished",
+							Computed:            true,
+						},
+						"file_name": schema.StringAttribute{
+							MarkdownDescription: "Name of the exported file",
+							Computed:            true,
+						},
+						"error": schema.StringAttribute{
+							MarkdownDescription: "Error the run failed with",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *CostExportResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data CostExportResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateCostTimePeriod(ctx, data.Timeframe, data.TimePeriod)...)
+}
+
+func (r *CostExportResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(AzurexContext)
+	if !ok {
+		resp.Diagnostics.AddError("unable to obtain provider data", "provider data not available")
+		return
+	}
+
+	exportsClient, err := subscriptionSettings.NewExportsClient(data.IdentityCreds, data.ClientOptions)
+	if err != nil {
+		resp.Diagnostics.AddError("unable to configure exports client", fmt.Sprintf("got: %s", err.Error()))
+		return
+	}
+	r.ExportsClient = exportsClient
+}
+
+func (r *CostExportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *CostExportResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "creating cost export resource")
+
+	export, diags := data.export(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	scope := strings.TrimSuffix(data.Scope.ValueString(), "/")
+	if _, err := r.ExportsClient.CreateOrUpdate(ctx, scope, data.Name.ValueString(), export); err != nil {
+		resp.Diagnostics.Append(responseErrorDiagnostic("Error creating cost export", err))
+		return
+	}
+
+	data.ID = types.StringValue(costResourceID(scope, "exports", data.Name.ValueString()))
+
+	resp.Diagnostics.Append(r.runAndRefresh(ctx, data, data.RunNow.ValueBool())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CostExportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *CostExportResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	scope := strings.TrimSuffix(data.Scope.ValueString(), "/")
+	export, err := r.ExportsClient.Get(ctx, scope, data.Name.ValueString(), true)
+	if subscriptionSettings.IsNotFound(err) {
+		tflog.Debug(ctx, fmt.Sprintf("cost export %s no longer exists, removing from state", data.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(responseErrorDiagnostic("Error reading cost export", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.refresh(ctx, export)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CostExportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *CostExportResourceModel
+	var state *CostExportResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updating cost export resource")
+
+	export, diags := data.export(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Updates are rejected unless they carry the current eTag
+	scope := strings.TrimSuffix(data.Scope.ValueString(), "/")
+	current, err := r.ExportsClient.Get(ctx, scope, data.Name.ValueString(), false)
+	if err != nil {
+		resp.Diagnostics.Append(responseErrorDiagnostic("Error reading cost export", err))
+		return
+	}
+	export.ETag = current.ETag
+
+	if _, err := r.ExportsClient.CreateOrUpdate(ctx, scope, data.Name.ValueString(), export); err != nil {
+		resp.Diagnostics.Append(responseErrorDiagnostic("Error updating cost export", err))
+		return
+	}
+
+	// Only run when run_now is switched on, not on every update that keeps it
+	runNow := data.RunNow.ValueBool() && !state.RunNow.ValueBool()
+	resp.Diagnostics.Append(r.runAndRefresh(ctx, data, runNow)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CostExportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *CostExportResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "deleting cost export resource")
+
+	scope := strings.TrimSuffix(data.Scope.ValueString(), "/")
+	if err := r.ExportsClient.Delete(ctx, scope, data.Name.ValueString()); err != nil {
+		resp.Diagnostics.Append(responseErrorDiagnostic("Error deleting cost export", err))
+		return
+	}
+}
+
+// ImportState accepts the export ID, e.g.
+// /subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.CostManagement/exports/example.
+func (r *CostExportResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	scope, name, err := parseCostResourceID(req.ID, "exports")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("scope"), scope)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("run_now"), false)...)
+}
+
+// runAndRefresh runs the export when runNow is set and reads it back, so the
+// run shows up in run_history.
+func (r *CostExportResource) runAndRefresh(ctx context.Context, data *CostExportResourceModel, runNow bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	scope := strings.TrimSuffix(data.Scope.ValueString(), "/")
+	if runNow {
+		if err := r.ExportsClient.Execute(ctx, scope, data.Name.ValueString()); err != nil {
+			diags.Append(responseErrorDiagnostic("Error running cost export", err))
+			return diags
+		}
+	}
+
+	export, err := r.ExportsClient.Get(ctx, scope, data.Name.ValueString(), true)
+	if err != nil {
+		diags.Append(responseErrorDiagnostic("Error reading cost export", err))
+		return diags
+	}
+	diags.Append(data.refresh(ctx, export)...)
+	return diags
+}
+
+// export builds the export described by the model.
+func (m *CostExportResourceModel) export(ctx context.Context) (subscriptionSettings.Export, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	export := subscriptionSettings.Export{
+		Properties: subscriptionSettings.ExportProperties{
+			Definition: subscriptionSettings.ExportDefinition{
+				Type:      subscriptionSettings.ExportType(m.Type.ValueString()),
+				Timeframe: subscriptionSettings.TimeframeType(m.Timeframe.ValueString()),
+			},
+			DeliveryInfo: subscriptionSettings.ExportDeliveryInfo{
+				Destination: subscriptionSettings.ExportDeliveryDestination{
+					ResourceID:     m.StorageAccountID.ValueString(),
+					Container:      m.Container.ValueString(),
+					RootFolderPath: m.RootFolderPath.ValueString(),
+				},
+			},
+			Format:        subscriptionSettings.FormatType(m.Format.ValueString()),
+			PartitionData: m.PartitionData.ValueBool(),
+			Schedule: &subscriptionSettings.ExportSchedule{
+				Status: subscriptionSettings.StatusTypeInactive,
+			},
+		},
+	}
+
+	if !m.TimePeriod.IsNull() && !m.TimePeriod.IsUnknown() {
+		from, to, d := costTimePeriod(ctx, m.TimePeriod)
+		diags.Append(d...)
+		export.Properties.Definition.TimePeriod = &subscriptionSettings.ExportTimePeriod{From: from, To: to}
+	}
+
+	var columns []string
+	if !m.Columns.IsNull() && !m.Columns.IsUnknown() {
+		diags.Append(m.Columns.ElementsAs(ctx, &columns, false)...)
+	}
+	granularity := m.Granularity.ValueString()
+	if granularity != "" || len(columns) > 0 {
+		export.Properties.Definition.DataSet = &subscriptionSettings.ExportDataset{
+			Granularity: subscriptionSettings.GranularityType(granularity),
+		}
+		if len(columns) > 0 {
+			export.Properties.Definition.DataSet.Configuration = &subscriptionSettings.ExportDatasetConfiguration{Columns: columns}
+		}
+	}
+
+	if !m.Schedule.IsNull() && !m.Schedule.IsUnknown() {
+		var schedule costExportScheduleModel
+		diags.Append(m.Schedule.As(ctx, &schedule, basetypes.ObjectAsOptions{})...)
+		from, err := parseCostTime(schedule.From)
+		if err != nil {
+			diags.AddAttributeError(path.Root("schedule").AtName("from"), "Invalid schedule", err.Error())
+		}
+		recurrencePeriod := &subscriptionSettings.ExportRecurrencePeriod{From: from}
+		if !schedule.To.IsNull() && !schedule.To.IsUnknown() {
+			to, err := parseCostTime(schedule.To)
+			if err != nil {
+				diags.AddAttributeError(path.Root("schedule").AtName("to"), "Invalid schedule", err.Error())
+			}
+			recurrencePeriod.To = &to
+		}
+
+		status := subscriptionSettings.StatusTypeActive
+		if !schedule.Active.IsNull() && !schedule.Active.IsUnknown() && !schedule.Active.ValueBool() {
+			status = subscriptionSettings.StatusTypeInactive
+		}
+		export.Properties.Schedule = &subscriptionSettings.ExportSchedule{
+			Recurrence:       subscriptionSettings.RecurrenceType(schedule.Recurrence.ValueString()),
+			RecurrencePeriod: recurrencePeriod,
+			Status:           status,
+		}
+	}
+
+	return export, diags
+}
+
+// refresh updates the model from export.
+func (m *CostExportResourceModel) refresh(ctx context.Context, export subscriptionSettings.Export) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var d diag.Diagnostics
+
+	properties := export.Properties
+	definition := properties.Definition
+
+	if export.ID != "" {
+		m.ID = types.StringValue(export.ID)
+	}
+	m.Type = types.StringValue(string(definition.Type))
+	m.Timeframe = types.StringValue(string(definition.Timeframe))
+	m.Format = types.StringValue(string(properties.Format))
+	if properties.Format == "" {
+		m.Format = types.StringValue(string(subscriptionSettings.FormatTypeCSV))
+	}
+	m.PartitionData = types.BoolValue(properties.PartitionData)
+	m.StorageAccountID = types.StringValue(properties.DeliveryInfo.Destination.ResourceID)
+	m.Container = types.StringValue(properties.DeliveryInfo.Destination.Container)
+	m.RootFolderPath = stringValueOrNull(properties.DeliveryInfo.Destination.RootFolderPath)
+
+	var currentTimePeriod costExportTimePeriodModel
+	if !m.TimePeriod.IsNull() && !m.TimePeriod.IsUnknown() {
+		diags.Append(m.TimePeriod.As(ctx, &currentTimePeriod, basetypes.ObjectAsOptions{})...)
+	}
+	m.TimePeriod = types.ObjectNull(costExportTimePeriodAttrTypes)
+	if definition.TimePeriod != nil {
+		m.TimePeriod, d = types.ObjectValueFrom(ctx, costExportTimePeriodAttrTypes, costExportTimePeriodModel{
+			From: costTimeValue(currentTimePeriod.From, &definition.TimePeriod.From),
+			To:   costTimeValue(currentTimePeriod.To, &definition.TimePeriod.To),
+		})
+		diags.Append(d...)
+	}
+
+	m.Granularity = types.StringNull()
+	m.Columns = types.ListNull(types.StringType)
+	if definition.DataSet != nil {
+		m.Granularity = stringValueOrNull(string(definition.DataSet.Granularity))
+		if definition.DataSet.Configuration != nil && len(definition.DataSet.Configuration.Columns) > 0 {
+			m.Columns, d = types.ListValueFrom(ctx, types.StringType, definition.DataSet.Configuration.Columns)
+			diags.Append(d...)
+		}
+	}
+
+	var currentSchedule costExportScheduleModel
+	if !m.Schedule.IsNull() && !m.Schedule.IsUnknown() {
+		diags.Append(m.Schedule.As(ctx, &currentSchedule, basetypes.ObjectAsOptions{})...)
+	}
+	m.Schedule = types.ObjectNull(costExportScheduleAttrTypes)
+	if schedule := properties.Schedule; schedule != nil && schedule.Recurrence != "" {
+		value := costExportScheduleModel{
+			Recurrence: types.StringValue(string(schedule.Recurrence)),
+			From:       types.StringNull(),
+			To:         types.StringNull(),
+			Active:     types.BoolValue(schedule.Status != subscriptionSettings.StatusTypeInactive),
+		}
+		if schedule.RecurrencePeriod != nil {
+			value.From = costTimeValue(currentSchedule.From, &schedule.RecurrencePeriod.From)
+			value.To = costTimeValue(currentSchedule.To, schedule.RecurrencePeriod.To)
+		}
+		m.Schedule, d = types.ObjectValueFrom(ctx, costExportScheduleAttrTypes, value)
+		diags.Append(d...)
+	}
+
+	m.NextRunTimeEstimate = costTimeValue(types.StringNull(), properties.NextRunTimeEstimate)
+
+	runs := make([]costExportRunModel, 0)
+	if properties.RunHistory != nil {
+		for _, run := range properties.RunHistory.Value {
+			runError := types.StringNull()
+			if run.Properties.Error != nil {
+				runError = types.StringValue(strings.TrimSpace(run.Properties.Error.Code + " " + run.Properties.Error.Message))
+			}
+			runs = append(runs, costExportRunModel{
+				ExecutionType:       stringValueOrNull(string(run.Properties.ExecutionType)),
+				Status:              stringValueOrNull(string(run.Properties.Status)),
+				SubmittedBy:         stringValueOrNull(run.Properties.SubmittedBy),
+				SubmittedTime:       costTimeValue(types.StringNull(), run.Properties.SubmittedTime),
+				ProcessingStartTime: costTimeValue(types.StringNull(), run.Properties.ProcessingStartTime),
+				ProcessingEndTime:   costTimeValue(types.StringNull(), run.Properties.ProcessingEndTime),
+				FileName:            stringValueOrNull(run.Properties.FileName),
+				Error:               runError,
+			})
+		}
+	}
+	m.RunHistory, d = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: costExportRunAttrTypes}, runs)
+	diags.Append(d...)
+
+	return diags
+}
@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccCostExportResource(t *testing.T) {
+	skipWithoutTerraform(t)
+	fake := newFakeARM(t)
+	id := fakeSubscriptionScope + "/providers/Microsoft.CostManagement/exports/monthly"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fake.providerFactories(),
+		CheckDestroy: func(*terraform.State) error {
+			if fake.getCostResourceAt(id) != nil {
+				return fmt.Errorf("expected export %s to be deleted", id)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + testAccCostExportResourceConfig("exports", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("azurex_cost_export.test", "id", id),
+					resource.TestCheckResourceAttr("azurex_cost_export.test", "type", "ActualCost"),
+					resource.TestCheckResourceAttr("azurex_cost_export.test", "format", "Csv"),
+					resource.TestCheckResourceAttr("azurex_cost_export.test", "schedule.active", "true"),
+					resource.TestCheckResourceAttr("azurex_cost_export.test", "next_run_time_estimate", "2030-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr("azurex_cost_export.test", "run_history.#", "1"),
+					resource.TestCheckResourceAttr("azurex_cost_export.test", "run_history.0.execution_type", "OnDemand"),
+					resource.TestCheckResourceAttr("azurex_cost_export.test", "run_history.0.status", "Completed"),
+				),
+			},
+			{
+				Config: fake.providerConfig() + testAccCostExportResourceConfig("finops", `granularity = "Daily"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("azurex_cost_export.test", "container", "finops"),
+					resource.TestCheckResourceAttr("azurex_cost_export.test", "granularity", "Daily"),
+					// run_now was already true, so the update does not run it again
+					resource.TestCheckResourceAttr("azurex_cost_export.test", "run_history.#", "1"),
+				),
+			},
+			{
+				ResourceName:            "azurex_cost_export.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"run_now"},
+			},
+		},
+	})
+}
+
+func TestAccCostExportResource_customTimeframe(t *testing.T) {
+	skipWithoutTerraform(t)
+	fake := newFakeARM(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fake.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+resource "azurex_cost_export" "test" {
+  name               = "custom"
+  scope              = "/subscriptions/00000000-0000-0000-0000-000000000000"
+  timeframe          = "Custom"
+  storage_account_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/example"
+  container          = "exports"
+}
+`,
+				ExpectError: regexp.MustCompile(`time_period attribute must be set`),
+			},
+			{
+				Config: fake.providerConfig() + `
+resource "azurex_cost_export" "test" {
+  name               = "custom"
+  scope              = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example"
+  timeframe          = "Custom"
+  storage_account_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/example"
+  container          = "exports"
+
+  time_period = {
+    from = "2030-01-01T00:00:00Z"
+    to   = "2030-01-31T00:00:00+00:00"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("azurex_cost_export.test", "time_period.to", "2030-01-31T00:00:00+00:00"),
+					resource.TestCheckNoResourceAttr("azurex_cost_export.test", "schedule"),
+					resource.TestCheckResourceAttr("azurex_cost_export.test", "run_history.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestParseCostResourceID(t *testing.T) {
+	scope, name, err := parseCostResourceID("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.CostManagement/exports/monthly", "exports")
+	if err != nil {
+		t.Fatalf("parsing export ID: %s", err)
+	}
+	if scope != "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example" || name != "monthly" {
+		t.Fatalf("unexpected scope %q and name %q", scope, name)
+	}
+
+	for _, id := range []string{
+		"/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.CostManagement/views/monthly",
+		"/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.CostManagement/exports/",
+		"/providers/Microsoft.Compute/virtualMachines/example/providers/Microsoft.CostManagement/exports/monthly",
+	} {
+		if _, _, err := parseCostResourceID(id, "exports"); err == nil {
+			t.Errorf("expected %q to be rejected", id)
+		}
+	}
+}
+
+func testAccCostExportResourceConfig(container string, extra string) string {
+	return fmt.Sprintf(`
+resource "azurex_cost_export" "test" {
+  name               = "monthly"
+  scope              = "/subscriptions/00000000-0000-0000-0000-000000000000"
+  timeframe          = "MonthToDate"
+  storage_account_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/example"
+  container          = %q
+  root_folder_path   = "costs"
+  run_now            = true
+  %s
+
+  schedule = {
+    recurrence = "Daily"
+    from       = "2030-01-01T00:00:00Z"
+  }
+}
+`, container, extra)
+}
@@ -0,0 +1,382 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	subscriptionSettings "github.com/ekristen/terraform-provider-azurex/internal/azure/subscriptions"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CostForecastDataSource{}
+
+// costForecastGranularityMonthly rolls the daily forecast up by month, the
+// forecast API itself only supports daily rows.
+const costForecastGranularityMonthly = "Monthly"
+
+func NewCostForecastDataSource() datasource.DataSource {
+	return &CostForecastDataSource{}
+}
+
+// CostForecastDataSource defines the data source implementation.
+type CostForecastDataSource struct {
+	ForecastClient *subscriptionSettings.ForecastClient
+}
+
+// CostForecastDataSourceModel describes the data source data model.
+type CostForecastDataSourceModel struct {
+	Scope                   types.String `tfsdk:"scope"`
+	Type                    types.String `tfsdk:"type"`
+	TimePeriod              types.Object `tfsdk:"time_period"`
+	Granularity             types.String `tfsdk:"granularity"`
+	Aggregation             types.Map    `tfsdk:"aggregation"`
+	Filter                  types.Object `tfsdk:"filter"`
+	IncludeActualCost       types.Bool   `tfsdk:"include_actual_cost"`
+	IncludeFreshPartialCost types.Bool   `tfsdk:"include_fresh_partial_cost"`
+	Columns                 types.List   `tfsdk:"columns"`
+	Rows                    types.List   `tfsdk:"rows"`
+	Totals                  types.Map    `tfsdk:"totals"`
+}
+
+func (d *CostForecastDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cost_forecast"
+}
+
+func (d *CostForecastDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Forecasts the costs of a scope over a date range, optionally including the actual costs so far",
+
+		Attributes: map[string]schema.Attribute{
+			"scope": schema.StringAttribute{
+				MarkdownDescription: "Scope to forecast the costs of, " + costScopeDescription,
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(costScopePattern, "must be a subscription, resource group, management group or billing scope"),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Type of the forecasted costs, one of `ActualCost`, `AmortizedCost` or `Usage`. Defaults to `ActualCost`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(enumValues(subscriptionSettings.PossibleForecastTypeValues())...),
+				},
+			},
+			"time_period": schema.SingleNestedAttribute{
+				MarkdownDescription: "Date range to forecast",
+				Required:            true,
+				Attributes: map[string]schema.Attribute{
+					"from": schema.StringAttribute{
+						MarkdownDescription: "Start of the date range, as an RFC 3339 timestamp",
+						Required:            true,
+					},
+					"to": schema.StringAttribute{
+						MarkdownDescription: "End of the date range, as an RFC 3339 timestamp",
+						Required:            true,
+					},
+				},
+			},
+			"granularity": schema.StringAttribute{
+				MarkdownDescription: "Granularity of the rows, `Daily` or `Monthly`. Costs are aggregated over the date range when unset.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(append(enumValues(subscriptionSettings.PossibleGranularityTypeValues()), costForecastGranularityMonthly)...),
+				},
+			},
+			"aggregation": schema.MapNestedAttribute{
+				MarkdownDescription: "Aggregated columns keyed by their alias, e.g. `totalCost`, at most 2",
+				Required:            true,
+				Validators: []validator.Map{
+					mapvalidator.SizeBetween(1, 2),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"column": schema.StringAttribute{
+							MarkdownDescription: "Column to aggregate, one of `Cost`, `CostUSD`, `PreTaxCost` or `PreTaxCostUSD`",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(enumValues(subscriptionSettings.PossibleFunctionNameValues())...),
+							},
+						},
+						"function": schema.StringAttribute{
+							MarkdownDescription: "Aggregation function, only `Sum` is supported. Defaults to `Sum`.",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(enumValues(subscriptionSettings.PossibleFunctionTypeValues())...),
+							},
+						},
+					},
+				},
+			},
+			"filter": schema.SingleNestedAttribute{
+				MarkdownDescription: "Restricts the forecasted costs, every comparison must match",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"dimensions": schema.ListNestedAttribute{
+						MarkdownDescription: "Comparisons against dimensions, e.g. `ResourceGroupName`",
+						Optional:            true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: costQueryComparisonAttributes("dimension"),
+						},
+					},
+					"tags": schema.ListNestedAttribute{
+						MarkdownDescription: "Comparisons against tags",
+						Optional:            true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: costQueryComparisonAttributes("tag key"),
+						},
+					},
+				},
+			},
+			"include_actual_cost": schema.BoolAttribute{
+				MarkdownDescription: "Include the actual costs of the days of the date range that have passed, marked `Actual` in the `CostStatus` column",
+				Optional:            true,
+			},
+			"include_fresh_partial_cost": schema.BoolAttribute{
+				MarkdownDescription: "Include the partial costs of the current day",
+				Optional:            true,
+			},
+			"columns": schema.ListNestedAttribute{
+				MarkdownDescription: "Columns of the result",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the column, the aggregation alias for aggregated columns",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Type of the column, e.g. `Number` or `String`",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"rows": schema.ListNestedAttribute{
+				MarkdownDescription: "Rows of the result",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"numbers": schema.MapAttribute{
+							MarkdownDescription: "Values of the `Number` columns keyed by column name, e.g. the aggregated costs and `UsageDate`. " +
+								"Monthly rows are dated on the first of the month.",
+							ElementType: types.Float64Type,
+							Computed:    true,
+						},
+						"strings": schema.MapAttribute{
+							MarkdownDescription: "Values of the other columns keyed by column name, e.g. `CostStatus` and `Currency`",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"totals": schema.MapAttribute{
+				MarkdownDescription: "Sum of every aggregated column over all rows keyed by alias, including actual costs when `include_actual_cost` is set",
+				ElementType:         types.Float64Type,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *CostForecastDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(AzurexContext)
+	if !ok {
+		resp.Diagnostics.AddError("unable to obtain provider data", "provider data not available")
+		return
+	}
+
+	forecastClient, err := subscriptionSettings.NewForecastClient(data.IdentityCreds, data.ClientOptions)
+	if err != nil {
+		resp.Diagnostics.AddError("unable to configure forecast client", fmt.Sprintf("got: %s", err.Error()))
+		return
+	}
+	d.ForecastClient = forecastClient
+}
+
+func (d *CostForecastDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CostForecastDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	forecast, diags := data.forecast(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	scope := strings.TrimSuffix(data.Scope.ValueString(), "/")
+	result, err := d.ForecastClient.Usage(ctx, scope, forecast)
+	if err != nil {
+		resp.Diagnostics.Append(responseErrorDiagnostic("Error forecasting costs", err))
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("read %d forecast rows for %s", len(result.Properties.Rows), scope))
+
+	rows := result.Properties.Rows
+	if data.Granularity.ValueString() == costForecastGranularityMonthly {
+		rows = costForecastMonthly(result.Properties.Columns, rows, forecast.Dataset.Aggregation)
+	}
+
+	data.Columns, data.Rows, diags = costQueryResult(ctx, result.Properties.Columns, rows)
+	resp.Diagnostics.Append(diags...)
+
+	totals := make(map[string]float64, len(forecast.Dataset.Aggregation))
+	for alias := range forecast.Dataset.Aggregation {
+		totals[alias] = costQueryTotal(result.Properties.Columns, rows, alias)
+	}
+	data.Totals, diags = types.MapValueFrom(ctx, types.Float64Type, totals)
+	resp.Diagnostics.Append(diags...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// forecast builds the forecast described by the model.
+func (m *CostForecastDataSourceModel) forecast(ctx context.Context) (subscriptionSettings.ForecastDefinition, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	forecast := subscriptionSettings.ForecastDefinition{
+		Type:                    subscriptionSettings.ForecastTypeActualCost,
+		Timeframe:               subscriptionSettings.ForecastTimeframeCustom,
+		IncludeActualCost:       m.IncludeActualCost.ValueBoolPointer(),
+		IncludeFreshPartialCost: m.IncludeFreshPartialCost.ValueBoolPointer(),
+	}
+	if !m.Type.IsNull() {
+		forecast.Type = subscriptionSettings.ForecastType(m.Type.ValueString())
+	}
+	if !m.Granularity.IsNull() {
+		// Monthly rows are rolled up from the daily forecast
+		forecast.Dataset.Granularity = subscriptionSettings.GranularityTypeDaily
+	}
+
+	from, to, d := costTimePeriod(ctx, m.TimePeriod)
+	diags.Append(d...)
+	forecast.TimePeriod = &subscriptionSettings.QueryTimePeriod{From: from, To: to}
+
+	var aggregation map[string]costViewAggregationModel
+	diags.Append(m.Aggregation.ElementsAs(ctx, &aggregation, false)...)
+	forecast.Dataset.Aggregation = make(map[string]subscriptionSettings.ForecastAggregation, len(aggregation))
+	for alias, a := range aggregation {
+		function := subscriptionSettings.FunctionTypeSum
+		if !a.Function.IsNull() {
+			function = subscriptionSettings.FunctionType(a.Function.ValueString())
+		}
+		forecast.Dataset.Aggregation[alias] = subscriptionSettings.ForecastAggregation{
+			Name:     subscriptionSettings.FunctionName(a.Column.ValueString()),
+			Function: function,
+		}
+	}
+
+	if !m.Filter.IsNull() {
+		var filter costQueryFilterModel
+		diags.Append(m.Filter.As(ctx, &filter, basetypes.ObjectAsOptions{})...)
+		expressions, d := costQueryFilters(ctx, filter)
+		diags.Append(d...)
+
+		var filters []subscriptionSettings.ForecastFilter
+		for _, e := range expressions {
+			filters = append(filters, subscriptionSettings.ForecastFilter{
+				Dimensions: forecastComparison(e.Dimensions),
+				Tags:       forecastComparison(e.Tags),
+			})
+		}
+		switch len(filters) {
+		case 0:
+		case 1:
+			forecast.Dataset.Filter = &filters[0]
+		default:
+			forecast.Dataset.Filter = &subscriptionSettings.ForecastFilter{And: filters}
+		}
+	}
+
+	return forecast, diags
+}
+
+// forecastComparison converts a query comparison to its forecast equivalent.
+func forecastComparison(c *subscriptionSettings.QueryComparisonExpression) *subscriptionSettings.ForecastComparisonExpression {
+	if c == nil {
+		return nil
+	}
+	return &subscriptionSettings.ForecastComparisonExpression{
+		Name:     c.Name,
+		Operator: subscriptionSettings.ForecastOperatorType(c.Operator),
+		Values:   c.Values,
+	}
+}
+
+// costForecastMonthly rolls daily forecast rows up by month, summing the
+// aggregated columns of rows that share the month and every other column,
+// e.g. CostStatus and Currency. UsageDate becomes the first of the month.
+func costForecastMonthly(columns []subscriptionSettings.QueryColumn, rows [][]any, aggregation map[string]subscriptionSettings.ForecastAggregation) [][]any {
+	usageDate := -1
+	aggregated := make(map[int]bool)
+	for i, c := range columns {
+		if strings.EqualFold(c.Name, "UsageDate") {
+			usageDate = i
+		}
+		if _, ok := aggregation[c.Name]; ok {
+			aggregated[i] = true
+		}
+	}
+	if usageDate < 0 {
+		return rows
+	}
+
+	var keys []string
+	months := make(map[string][]any)
+	for _, row := range rows {
+		month := append([]any(nil), row...)
+		if date, ok := row[usageDate].(float64); ok {
+			month[usageDate] = float64(int(date)/100*100 + 1)
+		}
+
+		var key []string
+		for i, value := range month {
+			if !aggregated[i] {
+				key = append(key, fmt.Sprint(value))
+			}
+		}
+		id := strings.Join(key, "\x00")
+
+		existing, ok := months[id]
+		if !ok {
+			keys = append(keys, id)
+			months[id] = month
+			continue
+		}
+		for i := range aggregated {
+			a, _ := existing[i].(float64)
+			b, _ := month[i].(float64)
+			existing[i] = a + b
+		}
+	}
+
+	result := make([][]any, 0, len(keys))
+	for _, id := range keys {
+		result = append(result, months[id])
+	}
+	return result
+}
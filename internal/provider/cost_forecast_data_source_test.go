@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCostForecastDataSource(t *testing.T) {
+	skipWithoutTerraform(t)
+	fake := newFakeARM(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fake.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+data "azurex_cost_forecast" "test" {
+  scope               = "/subscriptions/00000000-0000-0000-0000-000000000000"
+  granularity         = "Monthly"
+  include_actual_cost = true
+
+  time_period = {
+    from = "2030-01-01T00:00:00Z"
+    to   = "2030-02-28T00:00:00Z"
+  }
+
+  aggregation = {
+    totalCost = {
+      column = "Cost"
+    }
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.azurex_cost_forecast.test", "columns.#", "4"),
+					resource.TestCheckResourceAttr("data.azurex_cost_forecast.test", "rows.#", "3"),
+					resource.TestCheckResourceAttr("data.azurex_cost_forecast.test", "rows.0.numbers.totalCost", "16"),
+					resource.TestCheckResourceAttr("data.azurex_cost_forecast.test", "rows.0.numbers.UsageDate", "20300101"),
+					resource.TestCheckResourceAttr("data.azurex_cost_forecast.test", "rows.0.strings.CostStatus", "Actual"),
+					resource.TestCheckResourceAttr("data.azurex_cost_forecast.test", "rows.1.numbers.totalCost", "290"),
+					resource.TestCheckResourceAttr("data.azurex_cost_forecast.test", "rows.1.strings.CostStatus", "Forecast"),
+					resource.TestCheckResourceAttr("data.azurex_cost_forecast.test", "rows.2.numbers.totalCost", "280"),
+					resource.TestCheckResourceAttr("data.azurex_cost_forecast.test", "rows.2.numbers.UsageDate", "20300201"),
+					resource.TestCheckResourceAttr("data.azurex_cost_forecast.test", "totals.totalCost", "586"),
+				),
+			},
+			{
+				Config: fake.providerConfig() + `
+data "azurex_cost_forecast" "test" {
+  scope = "/subscriptions/00000000-0000-0000-0000-000000000000"
+
+  time_period = {
+    from = "2030-01-01T00:00:00Z"
+    to   = "2030-02-28T00:00:00Z"
+  }
+
+  aggregation = {
+    totalCost = {
+      column = "Cost"
+    }
+  }
+
+  filter = {
+    dimensions = [
+      { name = "ResourceGroupName", values = ["app"] },
+    ]
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.azurex_cost_forecast.test", "rows.#", "1"),
+					resource.TestCheckResourceAttr("data.azurex_cost_forecast.test", "rows.0.strings.CostStatus", "Forecast"),
+					resource.TestCheckResourceAttr("data.azurex_cost_forecast.test", "totals.totalCost", "570"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,493 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	subscriptionSettings "github.com/ekristen/terraform-provider-azurex/internal/azure/subscriptions"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CostQueryDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &CostQueryDataSource{}
+
+func NewCostQueryDataSource() datasource.DataSource {
+	return &CostQueryDataSource{}
+}
+
+// CostQueryDataSource defines the data source implementation.
+type CostQueryDataSource struct {
+	QueryClient *subscriptionSettings.QueryClient
+}
+
+// CostQueryDataSourceModel describes the data source data model.
+type CostQueryDataSourceModel struct {
+	Scope       types.String `tfsdk:"scope"`
+	Type        types.String `tfsdk:"type"`
+	Timeframe   types.String `tfsdk:"timeframe"`
+	TimePeriod  types.Object `tfsdk:"time_period"`
+	Granularity types.String `tfsdk:"granularity"`
+	Aggregation types.Map    `tfsdk:"aggregation"`
+	Grouping    types.List   `tfsdk:"grouping"`
+	Filter      types.Object `tfsdk:"filter"`
+	Columns     types.List   `tfsdk:"columns"`
+	Rows        types.List   `tfsdk:"rows"`
+	Totals      types.Map    `tfsdk:"totals"`
+}
+
+// costQueryFilterModel describes the filter attribute.
+type costQueryFilterModel struct {
+	Dimensions types.List `tfsdk:"dimensions"`
+	Tags       types.List `tfsdk:"tags"`
+}
+
+// costQueryComparisonModel is a single entry of filter.dimensions or
+// filter.tags.
+type costQueryComparisonModel struct {
+	Name     types.String `tfsdk:"name"`
+	Operator types.String `tfsdk:"operator"`
+	Values   types.List   `tfsdk:"values"`
+}
+
+// costQueryColumnModel is a single entry of columns.
+type costQueryColumnModel struct {
+	Name types.String `tfsdk:"name"`
+	Type types.String `tfsdk:"type"`
+}
+
+var costQueryColumnAttrTypes = map[string]attr.Type{
+	"name": types.StringType,
+	"type": types.StringType,
+}
+
+// costQueryRowModel is a single entry of rows.
+type costQueryRowModel struct {
+	Numbers types.Map `tfsdk:"numbers"`
+	Strings types.Map `tfsdk:"strings"`
+}
+
+var costQueryRowAttrTypes = map[string]attr.Type{
+	"numbers": types.MapType{ElemType: types.Float64Type},
+	"strings": types.MapType{ElemType: types.StringType},
+}
+
+func (d *CostQueryDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cost_query"
+}
+
+func (d *CostQueryDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Runs a Cost Management query at a scope and returns the resulting rows",
+
+		Attributes: map[string]schema.Attribute{
+			"scope": schema.StringAttribute{
+				MarkdownDescription: "Scope to query the costs of, " + costScopeDescription,
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(costScopePattern, "must be a subscription, resource group, management group or billing scope"),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Type of the queried costs, one of `ActualCost`, `AmortizedCost` or `Usage`. Defaults to `ActualCost`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(enumValues(subscriptionSettings.PossibleExportTypeValues())...),
+				},
+			},
+			"timeframe": schema.StringAttribute{
+				MarkdownDescription: "Time frame of the queried costs, e.g. `MonthToDate`. `Custom` requires `time_period`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(enumValues(subscriptionSettings.PossibleTimeframeTypeValues())...),
+				},
+			},
+			"time_period": schema.SingleNestedAttribute{
+				MarkdownDescription: "Date range of the queried costs, only valid with the `Custom` timeframe",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"from": schema.StringAttribute{
+						MarkdownDescription: "Start of the date range, as an RFC 3339 timestamp",
+						Required:            true,
+					},
+					"to": schema.StringAttribute{
+						MarkdownDescription: "End of the date range, as an RFC 3339 timestamp",
+						Required:            true,
+					},
+				},
+			},
+			"granularity": schema.StringAttribute{
+				MarkdownDescription: "Granularity of the rows, only `Daily` is supported. Costs are aggregated over the time frame when unset.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(enumValues(subscriptionSettings.PossibleGranularityTypeValues())...),
+				},
+			},
+			"aggregation": schema.MapNestedAttribute{
+				MarkdownDescription: "Aggregated columns keyed by their alias, e.g. `totalCost`, at most 2",
+				Required:            true,
+				Validators: []validator.Map{
+					mapvalidator.SizeBetween(1, 2),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"column": schema.StringAttribute{
+							MarkdownDescription: "Column to aggregate, e.g. `Cost` or `PreTaxCost`",
+							Required:            true,
+						},
+						"function": schema.StringAttribute{
+							MarkdownDescription: "Aggregation function, only `Sum` is supported. Defaults to `Sum`.",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(enumValues(subscriptionSettings.PossibleFunctionTypeValues())...),
+							},
+						},
+					},
+				},
+			},
+			"grouping": schema.ListNestedAttribute{
+				MarkdownDescription: "Columns the costs are grouped by, at most 2",
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(2),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Type of the column, `Dimension` or `TagKey`",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(enumValues(subscriptionSettings.PossibleQueryColumnTypeValues())...),
+							},
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Dimension, e.g. `ResourceGroupName`, or tag key to group by",
+							Required:            true,
+						},
+					},
+				},
+			},
+			"filter": schema.SingleNestedAttribute{
+				MarkdownDescription: "Restricts the queried costs, every comparison must match",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"dimensions": schema.ListNestedAttribute{
+						MarkdownDescription: "Comparisons against dimensions, e.g. `ResourceGroupName`",
+						Optional:            true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: costQueryComparisonAttributes("dimension"),
+						},
+					},
+					"tags": schema.ListNestedAttribute{
+						MarkdownDescription: "Comparisons against tags",
+						Optional:            true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: costQueryComparisonAttributes("tag key"),
+						},
+					},
+				},
+			},
+			"columns": schema.ListNestedAttribute{
+				MarkdownDescription: "Columns of the result",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the column, the aggregation alias for aggregated columns",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Type of the column, e.g. `Number` or `String`",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"rows": schema.ListNestedAttribute{
+				MarkdownDescription: "Rows of the result",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"numbers": schema.MapAttribute{
+							MarkdownDescription: "Values of the `Number` columns keyed by column name, e.g. the aggregated costs and `UsageDate`",
+							ElementType:         types.Float64Type,
+							Computed:            true,
+						},
+						"strings": schema.MapAttribute{
+							MarkdownDescription: "Values of the other columns keyed by column name, e.g. the grouped dimensions and `Currency`",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"totals": schema.MapAttribute{
+				MarkdownDescription: "Sum of every aggregated column over all rows, keyed by alias",
+				ElementType:         types.Float64Type,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// costQueryComparisonAttributes returns the attributes of a comparison
+// against a kind of column, used by filter.dimensions and filter.tags.
+func costQueryComparisonAttributes(kind string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("Name of the %s to compare", kind),
+			Required:            true,
+		},
+		"operator": schema.StringAttribute{
+			MarkdownDescription: "Comparison operator, only `In` is supported. Defaults to `In`.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf(enumValues(subscriptionSettings.PossibleQueryOperatorTypeValues())...),
+			},
+		},
+		"values": schema.ListAttribute{
+			MarkdownDescription: "Values to compare against",
+			ElementType:         types.StringType,
+			Required:            true,
+			Validators: []validator.List{
+				listvalidator.SizeAtLeast(1),
+			},
+		},
+	}
+}
+
+func (d *CostQueryDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data CostQueryDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateCostTimePeriod(ctx, data.Timeframe, data.TimePeriod)...)
+}
+
+func (d *CostQueryDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(AzurexContext)
+	if !ok {
+		resp.Diagnostics.AddError("unable to obtain provider data", "provider data not available")
+		return
+	}
+
+	queryClient, err := subscriptionSettings.NewQueryClient(data.IdentityCreds, data.ClientOptions)
+	if err != nil {
+		resp.Diagnostics.AddError("unable to configure query client", fmt.Sprintf("got: %s", err.Error()))
+		return
+	}
+	d.QueryClient = queryClient
+}
+
+func (d *CostQueryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CostQueryDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, diags := data.query(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	scope := strings.TrimSuffix(data.Scope.ValueString(), "/")
+	result, err := d.QueryClient.Usage(ctx, scope, query)
+	if err != nil {
+		resp.Diagnostics.Append(responseErrorDiagnostic("Error querying costs", err))
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("read %d cost rows for %s", len(result.Properties.Rows), scope))
+
+	data.Columns, data.Rows, diags = costQueryResult(ctx, result.Properties.Columns, result.Properties.Rows)
+	resp.Diagnostics.Append(diags...)
+
+	totals := make(map[string]float64, len(query.Dataset.Aggregation))
+	for alias := range query.Dataset.Aggregation {
+		totals[alias] = costQueryTotal(result.Properties.Columns, result.Properties.Rows, alias)
+	}
+	data.Totals, diags = types.MapValueFrom(ctx, types.Float64Type, totals)
+	resp.Diagnostics.Append(diags...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// query builds the query described by the model.
+func (m *CostQueryDataSourceModel) query(ctx context.Context) (subscriptionSettings.QueryDefinition, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	query := subscriptionSettings.QueryDefinition{
+		Type:      subscriptionSettings.ExportTypeActualCost,
+		Timeframe: subscriptionSettings.TimeframeType(m.Timeframe.ValueString()),
+		Dataset: subscriptionSettings.QueryDataset{
+			Granularity: subscriptionSettings.GranularityType(m.Granularity.ValueString()),
+		},
+	}
+	if !m.Type.IsNull() {
+		query.Type = subscriptionSettings.ExportType(m.Type.ValueString())
+	}
+
+	if !m.TimePeriod.IsNull() {
+		from, to, d := costTimePeriod(ctx, m.TimePeriod)
+		diags.Append(d...)
+		query.TimePeriod = &subscriptionSettings.QueryTimePeriod{From: from, To: to}
+	}
+
+	var aggregation map[string]costViewAggregationModel
+	diags.Append(m.Aggregation.ElementsAs(ctx, &aggregation, false)...)
+	query.Dataset.Aggregation = make(map[string]subscriptionSettings.QueryAggregation, len(aggregation))
+	for alias, a := range aggregation {
+		function := subscriptionSettings.FunctionTypeSum
+		if !a.Function.IsNull() {
+			function = subscriptionSettings.FunctionType(a.Function.ValueString())
+		}
+		query.Dataset.Aggregation[alias] = subscriptionSettings.QueryAggregation{Name: a.Column.ValueString(), Function: function}
+	}
+
+	if !m.Grouping.IsNull() {
+		var grouping []costViewColumnModel
+		diags.Append(m.Grouping.ElementsAs(ctx, &grouping, false)...)
+		for _, g := range grouping {
+			query.Dataset.Grouping = append(query.Dataset.Grouping, subscriptionSettings.QueryGrouping{
+				Type: subscriptionSettings.QueryColumnType(g.Type.ValueString()),
+				Name: g.Name.ValueString(),
+			})
+		}
+	}
+
+	if !m.Filter.IsNull() {
+		var filter costQueryFilterModel
+		diags.Append(m.Filter.As(ctx, &filter, basetypes.ObjectAsOptions{})...)
+		expressions, d := costQueryFilters(ctx, filter)
+		diags.Append(d...)
+		switch len(expressions) {
+		case 0:
+		case 1:
+			query.Dataset.Filter = &expressions[0]
+		default:
+			query.Dataset.Filter = &subscriptionSettings.QueryFilter{And: expressions}
+		}
+	}
+
+	return query, diags
+}
+
+// costQueryFilters returns a filter expression for every comparison in
+// filter.
+func costQueryFilters(ctx context.Context, filter costQueryFilterModel) ([]subscriptionSettings.QueryFilter, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var expressions []subscriptionSettings.QueryFilter
+
+	comparisons := func(list types.List) []*subscriptionSettings.QueryComparisonExpression {
+		if list.IsNull() {
+			return nil
+		}
+		var models []costQueryComparisonModel
+		diags.Append(list.ElementsAs(ctx, &models, false)...)
+		result := make([]*subscriptionSettings.QueryComparisonExpression, 0, len(models))
+		for _, c := range models {
+			comparison := &subscriptionSettings.QueryComparisonExpression{
+				Name:     c.Name.ValueString(),
+				Operator: subscriptionSettings.QueryOperatorTypeIn,
+			}
+			if !c.Operator.IsNull() {
+				comparison.Operator = subscriptionSettings.QueryOperatorType(c.Operator.ValueString())
+			}
+			diags.Append(c.Values.ElementsAs(ctx, &comparison.Values, false)...)
+			result = append(result, comparison)
+		}
+		return result
+	}
+
+	for _, c := range comparisons(filter.Dimensions) {
+		expressions = append(expressions, subscriptionSettings.QueryFilter{Dimensions: c})
+	}
+	for _, c := range comparisons(filter.Tags) {
+		expressions = append(expressions, subscriptionSettings.QueryFilter{Tags: c})
+	}
+	return expressions, diags
+}
+
+// costQueryResult converts the columns and rows of a query result, splitting
+// every row into its number and string values.
+func costQueryResult(ctx context.Context, columns []subscriptionSettings.QueryColumn, rows [][]any) (types.List, types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	columnModels := make([]costQueryColumnModel, 0, len(columns))
+	for _, c := range columns {
+		columnModels = append(columnModels, costQueryColumnModel{Name: types.StringValue(c.Name), Type: types.StringValue(c.Type)})
+	}
+	columnList, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: costQueryColumnAttrTypes}, columnModels)
+	diags.Append(d...)
+
+	rowModels := make([]costQueryRowModel, 0, len(rows))
+	for _, row := range rows {
+		numbers := make(map[string]float64)
+		strs := make(map[string]string)
+		for i, value := range row {
+			if i >= len(columns) || value == nil {
+				continue
+			}
+			if n, ok := value.(float64); ok && strings.EqualFold(columns[i].Type, "Number") {
+				numbers[columns[i].Name] = n
+				continue
+			}
+			strs[columns[i].Name] = fmt.Sprint(value)
+		}
+
+		numberMap, d := types.MapValueFrom(ctx, types.Float64Type, numbers)
+		diags.Append(d...)
+		stringMap, d := types.MapValueFrom(ctx, types.StringType, strs)
+		diags.Append(d...)
+		rowModels = append(rowModels, costQueryRowModel{Numbers: numberMap, Strings: stringMap})
+	}
+	rowList, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: costQueryRowAttrTypes}, rowModels)
+	diags.Append(d...)
+
+	return columnList, rowList, diags
+}
+
+// costQueryTotal returns the sum of the column named name over rows.
+func costQueryTotal(columns []subscriptionSettings.QueryColumn, rows [][]any, name string) float64 {
+	var total float64
+	for i, c := range columns {
+		if c.Name != name {
+			continue
+		}
+		for _, row := range rows {
+			if i < len(row) {
+				if n, ok := row[i].(float64); ok {
+					total += n
+				}
+			}
+		}
+	}
+	return total
+}
@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCostQueryDataSource(t *testing.T) {
+	skipWithoutTerraform(t)
+	fake := newFakeARM(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fake.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+data "azurex_cost_query" "test" {
+  scope       = "/subscriptions/00000000-0000-0000-0000-000000000000"
+  timeframe   = "MonthToDate"
+  granularity = "Daily"
+
+  aggregation = {
+    totalCost = {
+      column = "Cost"
+    }
+  }
+
+  grouping = [
+    { type = "Dimension", name = "ServiceName" },
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.azurex_cost_query.test", "columns.#", "4"),
+					resource.TestCheckResourceAttr("data.azurex_cost_query.test", "columns.0.name", "totalCost"),
+					resource.TestCheckResourceAttr("data.azurex_cost_query.test", "columns.0.type", "Number"),
+					resource.TestCheckResourceAttr("data.azurex_cost_query.test", "rows.#", "4"),
+					resource.TestCheckResourceAttr("data.azurex_cost_query.test", "rows.0.numbers.totalCost", "1.5"),
+					resource.TestCheckResourceAttr("data.azurex_cost_query.test", "rows.0.numbers.UsageDate", "20300101"),
+					resource.TestCheckResourceAttr("data.azurex_cost_query.test", "rows.0.strings.ServiceName", "Storage"),
+					resource.TestCheckResourceAttr("data.azurex_cost_query.test", "rows.3.strings.Currency", "USD"),
+					resource.TestCheckResourceAttr("data.azurex_cost_query.test", "totals.totalCost", "24"),
+				),
+			},
+			{
+				Config: fake.providerConfig() + `
+data "azurex_cost_query" "test" {
+  scope     = "/subscriptions/00000000-0000-0000-0000-000000000000"
+  type      = "AmortizedCost"
+  timeframe = "Custom"
+
+  time_period = {
+    from = "2030-01-01T00:00:00Z"
+    to   = "2030-01-31T00:00:00Z"
+  }
+
+  aggregation = {
+    totalCost = {
+      column   = "Cost"
+      function = "Sum"
+    }
+  }
+
+  grouping = [
+    { type = "Dimension", name = "ServiceName" },
+  ]
+
+  filter = {
+    dimensions = [
+      { name = "ResourceGroupName", values = ["app"] },
+      { name = "ServiceName", operator = "In", values = ["Storage", "Virtual Machines"] },
+    ]
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.azurex_cost_query.test", "rows.#", "2"),
+					resource.TestCheckResourceAttr("data.azurex_cost_query.test", "rows.1.strings.ServiceName", "Virtual Machines"),
+					resource.TestCheckResourceAttr("data.azurex_cost_query.test", "rows.1.numbers.totalCost", "20"),
+					resource.TestCheckResourceAttr("data.azurex_cost_query.test", "totals.totalCost", "21.5"),
+				),
+			},
+			{
+				Config: fake.providerConfig() + `
+data "azurex_cost_query" "test" {
+  scope     = "/subscriptions/00000000-0000-0000-0000-000000000000"
+  timeframe = "Custom"
+
+  aggregation = {
+    totalCost = {
+      column = "Cost"
+    }
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`time_period attribute must be set`),
+			},
+		},
+	})
+}
@@ -0,0 +1,659 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	subscriptionSettings "github.com/ekristen/terraform-provider-azurex/internal/azure/subscriptions"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &CostScheduledActionResource{}
+var _ resource.ResourceWithImportState = &CostScheduledActionResource{}
+var _ resource.ResourceWithValidateConfig = &CostScheduledActionResource{}
+
+// costSubscriptionScopePattern matches the subscription scope, the only
+// scope insight alerts can be created at.
+var costSubscriptionScopePattern = regexp.MustCompile(`(?i)^/subscriptions/[^/]+/?$`)
+
+func NewCostScheduledActionResource() resource.Resource {
+	return &CostScheduledActionResource{}
+}
+
+// CostScheduledActionResource defines the resource implementation.
+type CostScheduledActionResource struct {
+	ScheduledActionsClient *subscriptionSettings.ScheduledActionsClient
+}
+
+// CostScheduledActionResourceModel describes the resource data model.
+type CostScheduledActionResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	Scope             types.String `tfsdk:"scope"`
+	Kind              types.String `tfsdk:"kind"`
+	DisplayName       types.String `tfsdk:"display_name"`
+	Status            types.String `tfsdk:"status"`
+	ViewID            types.String `tfsdk:"view_id"`
+	NotificationEmail types.String `tfsdk:"notification_email"`
+	Notification      types.Object `tfsdk:"notification"`
+	Schedule          types.Object `tfsdk:"schedule"`
+	FileFormats       types.Set    `tfsdk:"file_formats"`
+}
+
+// costScheduledActionNotificationModel describes the notification attribute.
+type costScheduledActionNotificationModel struct {
+	To             types.List   `tfsdk:"to"`
+	Subject        types.String `tfsdk:"subject"`
+	Message        types.String `tfsdk:"message"`
+	Language       types.String `tfsdk:"language"`
+	RegionalFormat types.String `tfsdk:"regional_format"`
+}
+
+var costScheduledActionNotificationAttrTypes = map[string]attr.Type{
+	"to":              types.ListType{ElemType: types.StringType},
+	"subject":         types.StringType,
+	"message":         types.StringType,
+	"language":        types.StringType,
+	"regional_format": types.StringType,
+}
+
+// costScheduledActionScheduleModel describes the schedule attribute.
+type costScheduledActionScheduleModel struct {
+	Frequency    types.String `tfsdk:"frequency"`
+	HourOfDay    types.Int64  `tfsdk:"hour_of_day"`
+	DayOfMonth   types.Int64  `tfsdk:"day_of_month"`
+	DaysOfWeek   types.Set    `tfsdk:"days_of_week"`
+	WeeksOfMonth types.Set    `tfsdk:"weeks_of_month"`
+	StartDate    types.String `tfsdk:"start_date"`
+	EndDate      types.String `tfsdk:"end_date"`
+}
+
+var costScheduledActionScheduleAttrTypes = map[string]attr.Type{
+	"frequency":      types.StringType,
+	"hour_of_day":    types.Int64Type,
+	"day_of_month":   types.Int64Type,
+	"days_of_week":   types.SetType{ElemType: types.StringType},
+	"weeks_of_month": types.SetType{ElemType: types.StringType},
+	"start_date":     types.StringType,
+	"end_date":       types.StringType,
+}
+
+func (r *CostScheduledActionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cost_scheduled_action"
+}
+
+func (r *CostScheduledActionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Cost Management scheduled action, emails a cost analysis view on a schedule (`Email`) or notifies about cost anomalies (`InsightAlert`)",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the scheduled action",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the scheduled action. Changing this forces a new resource to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope": schema.StringAttribute{
+				MarkdownDescription: "Scope of the scheduled action, " + costScopeDescription + ". Insight alerts can only be created at a subscription. " +
+					"Changing this forces a new resource to be created.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(costScopePattern, "must be a subscription, resource group, management group or billing scope"),
+				},
+			},
+			"kind": schema.StringAttribute{
+				MarkdownDescription: "Kind of the scheduled action, `Email` to email a view or `InsightAlert` for anomaly alerts. Defaults to `Email`. " +
+					"Changing this forces a new resource to be created.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(string(subscriptionSettings.ScheduledActionKindEmail)),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(enumValues(subscriptionSettings.PossibleScheduledActionKindValues())...),
+				},
+			},
+			"display_name": schema.StringAttribute{
+				MarkdownDescription: "Display name of the scheduled action",
+				Required:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Status of the scheduled action, `Enabled` or `Disabled`. Defaults to `Enabled`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(string(subscriptionSettings.ScheduledActionStatusEnabled)),
+				Validators: []validator.String{
+					stringvalidator.OneOf(string(subscriptionSettings.ScheduledActionStatusEnabled), string(subscriptionSettings.ScheduledActionStatusDisabled)),
+				},
+			},
+			"view_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the cost analysis view to send, e.g. `/providers/Microsoft.CostManagement/views/ms:CostByService`. " +
+					"Insight alerts use `{scope}/providers/Microsoft.CostManagement/views/ms:DailyAnomalyByResourceGroup`.",
+				Required: true,
+			},
+			"notification_email": schema.StringAttribute{
+				MarkdownDescription: "Email address of the point of contact that receives unsubscribe requests and failure notifications",
+				Optional:            true,
+			},
+			"notification": schema.SingleNestedAttribute{
+				MarkdownDescription: "Email sent by the scheduled action",
+				Required:            true,
+				Attributes: map[string]schema.Attribute{
+					"to": schema.ListAttribute{
+						MarkdownDescription: "Email addresses to send to, at most 20",
+						ElementType:         types.StringType,
+						Required:            true,
+						Validators: []validator.List{
+							listvalidator.SizeBetween(1, 20),
+						},
+					},
+					"subject": schema.StringAttribute{
+						MarkdownDescription: "Subject of the email, at most 70 characters",
+						Required:            true,
+						Validators: []validator.String{
+							stringvalidator.LengthBetween(1, 70),
+						},
+					},
+					"message": schema.StringAttribute{
+						MarkdownDescription: "Message added to the email, at most 250 characters",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtMost(250),
+						},
+					},
+					"language": schema.StringAttribute{
+						MarkdownDescription: "Locale of the email, e.g. `en`",
+						Optional:            true,
+						Computed:            true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"regional_format": schema.StringAttribute{
+						MarkdownDescription: "Locale used to format dates, times and currencies in the email, e.g. `en-us`",
+						Optional:            true,
+						Computed:            true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+				},
+			},
+			"schedule": schema.SingleNestedAttribute{
+				MarkdownDescription: "Schedule of the scheduled action",
+				Required:            true,
+				Attributes: map[string]schema.Attribute{
+					"frequency": schema.StringAttribute{
+						MarkdownDescription: "How often the scheduled action runs, one of `Daily`, `Weekly` or `Monthly`. Insight alerts run `Daily`.",
+						Required:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(enumValues(subscriptionSettings.PossibleScheduleFrequencyValues())...),
+						},
+					},
+					"hour_of_day": schema.Int64Attribute{
+						MarkdownDescription: "UTC hour the scheduled action runs at, between 0 and 23",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.Between(0, 23),
+						},
+					},
+					"day_of_month": schema.Int64Attribute{
+						MarkdownDescription: "Day of the month a `Monthly` scheduled action runs on, between 1 and 31. Conflicts with `days_of_week` and `weeks_of_month`.",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.Between(1, 31),
+						},
+					},
+					"days_of_week": schema.SetAttribute{
+						MarkdownDescription: "Days a `Weekly` or `Monthly` scheduled action runs on, e.g. `Monday`",
+						ElementType:         types.StringType,
+						Optional:            true,
+						Validators: []validator.Set{
+							setvalidator.SizeAtLeast(1),
+							setvalidator.ValueStringsAre(stringvalidator.OneOf(enumValues(subscriptionSettings.PossibleDaysOfWeekValues())...)),
+						},
+					},
+					"weeks_of_month": schema.SetAttribute{
+						MarkdownDescription: "Weeks a `Monthly` scheduled action runs in together with `days_of_week`, one of `First`, `Second`, `Third`, `Fourth` or `Last`",
+						ElementType:         types.StringType,
+						Optional:            true,
+						Validators: []validator.Set{
+							setvalidator.SizeAtLeast(1),
+							setvalidator.ValueStringsAre(stringvalidator.OneOf(enumValues(subscriptionSettings.PossibleWeeksOfMonthValues())...)),
+						},
+					},
+					"start_date": schema.StringAttribute{
+						MarkdownDescription: "Start of the schedule, as an RFC 3339 timestamp",
+						Required:            true,
+					},
+					"end_date": schema.StringAttribute{
+						MarkdownDescription: "End of the schedule, as an RFC 3339 timestamp",
+						Required:            true,
+					},
+				},
+			},
+			"file_formats": schema.SetAttribute{
+				MarkdownDescription: "Formats the view data is attached to the email in, only `Csv` is supported. Not supported by insight alerts.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(enumValues(subscriptionSettings.PossibleFileFormatValues())...)),
+				},
+			},
+		},
+	}
+}
+
+func (r *CostScheduledActionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data CostScheduledActionResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Schedule.IsNull() || data.Schedule.IsUnknown() {
+		return
+	}
+
+	var schedule costScheduledActionScheduleModel
+	resp.Diagnostics.Append(data.Schedule.As(ctx, &schedule, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schedulePath := path.Root("schedule")
+	set := func(value attr.Value) bool { return !value.IsNull() }
+
+	if data.Kind.ValueString() == string(subscriptionSettings.ScheduledActionKindInsightAlert) {
+		if !data.Scope.IsUnknown() && !costSubscriptionScopePattern.MatchString(data.Scope.ValueString()) {
+			resp.Diagnostics.AddAttributeError(path.Root("scope"), "Unsupported scope",
+				"Insight alerts can only be created at a subscription scope.")
+		}
+		if !schedule.Frequency.IsUnknown() && schedule.Frequency.ValueString() != string(subscriptionSettings.ScheduleFrequencyDaily) {
+			resp.Diagnostics.AddAttributeError(schedulePath.AtName("frequency"), "Unsupported frequency",
+				"Insight alerts only support the Daily frequency.")
+		}
+		if set(data.FileFormats) {
+			resp.Diagnostics.AddAttributeError(path.Root("file_formats"), "Unexpected file formats",
+				"The file_formats attribute can only be set when kind is Email.")
+		}
+	}
+
+	switch subscriptionSettings.ScheduleFrequency(schedule.Frequency.ValueString()) {
+	case subscriptionSettings.ScheduleFrequencyDaily:
+		for name, value := range map[string]attr.Value{"day_of_month": schedule.DayOfMonth, "days_of_week": schedule.DaysOfWeek, "weeks_of_month": schedule.WeeksOfMonth} {
+			if set(value) {
+				resp.Diagnostics.AddAttributeError(schedulePath.AtName(name), "Unexpected schedule attribute",
+					fmt.Sprintf("The %s attribute can not be set when frequency is Daily.", name))
+			}
+		}
+	case subscriptionSettings.ScheduleFrequencyWeekly:
+		if !set(schedule.DaysOfWeek) {
+			resp.Diagnostics.AddAttributeError(schedulePath.AtName("days_of_week"), "Missing days of week",
+				"The days_of_week attribute must be set when frequency is Weekly.")
+		}
+		for name, value := range map[string]attr.Value{"day_of_month": schedule.DayOfMonth, "weeks_of_month": schedule.WeeksOfMonth} {
+			if set(value) {
+				resp.Diagnostics.AddAttributeError(schedulePath.AtName(name), "Unexpected schedule attribute",
+					fmt.Sprintf("The %s attribute can not be set when frequency is Weekly.", name))
+			}
+		}
+	case subscriptionSettings.ScheduleFrequencyMonthly:
+		byDay := set(schedule.DayOfMonth)
+		byWeek := set(schedule.DaysOfWeek) || set(schedule.WeeksOfMonth)
+		switch {
+		case byDay && byWeek:
+			resp.Diagnostics.AddAttributeError(schedulePath.AtName("day_of_month"), "Conflicting schedule attributes",
+				"The day_of_month attribute conflicts with days_of_week and weeks_of_month.")
+		case !byDay && !byWeek:
+			resp.Diagnostics.AddAttributeError(schedulePath.AtName("day_of_month"), "Missing monthly schedule",
+				"Either day_of_month, or days_of_week and weeks_of_month, must be set when frequency is Monthly.")
+		case byWeek && !(set(schedule.DaysOfWeek) && set(schedule.WeeksOfMonth)):
+			resp.Diagnostics.AddAttributeError(schedulePath.AtName("weeks_of_month"), "Incomplete monthly schedule",
+				"The days_of_week and weeks_of_month attributes must be set together.")
+		}
+	}
+
+	if schedule.StartDate.IsUnknown() || schedule.EndDate.IsUnknown() {
+		return
+	}
+	start, err := parseCostTime(schedule.StartDate)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(schedulePath.AtName("start_date"), "Invalid schedule", err.Error())
+	}
+	end, err := parseCostTime(schedule.EndDate)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(schedulePath.AtName("end_date"), "Invalid schedule", err.Error())
+	}
+	if !resp.Diagnostics.HasError() && !end.After(start) {
+		resp.Diagnostics.AddAttributeError(schedulePath.AtName("end_date"), "Invalid schedule",
+			"The end_date must be after start_date.")
+	}
+}
+
+func (r *CostScheduledActionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(AzurexContext)
+	if !ok {
+		resp.Diagnostics.AddError("unable to obtain provider data", "provider data not available")
+		return
+	}
+
+	scheduledActionsClient, err := subscriptionSettings.NewScheduledActionsClient(data.IdentityCreds, data.ClientOptions)
+	if err != nil {
+		resp.Diagnostics.AddError("unable to configure scheduled actions client", fmt.Sprintf("got: %s", err.Error()))
+		return
+	}
+	r.ScheduledActionsClient = scheduledActionsClient
+}
+
+func (r *CostScheduledActionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *CostScheduledActionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "creating cost scheduled action resource")
+
+	scheduledAction, diags := data.scheduledAction(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	scope := strings.TrimSuffix(data.Scope.ValueString(), "/")
+	result, err := r.ScheduledActionsClient.CreateOrUpdate(ctx, scope, data.Name.ValueString(), scheduledAction)
+	if err != nil {
+		resp.Diagnostics.Append(responseErrorDiagnostic("Error creating cost scheduled action", err))
+		return
+	}
+
+	data.ID = types.StringValue(costResourceID(scope, "scheduledActions", data.Name.ValueString()))
+
+	resp.Diagnostics.Append(data.refresh(ctx, result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CostScheduledActionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *CostScheduledActionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	scope := strings.TrimSuffix(data.Scope.ValueString(), "/")
+	scheduledAction, err := r.ScheduledActionsClient.Get(ctx, scope, data.Name.ValueString())
+	if subscriptionSettings.IsNotFound(err) {
+		tflog.Debug(ctx, fmt.Sprintf("cost scheduled action %s no longer exists, removing from state", data.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(responseErrorDiagnostic("Error reading cost scheduled action", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.refresh(ctx, scheduledAction)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CostScheduledActionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *CostScheduledActionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updating cost scheduled action resource")
+
+	scheduledAction, diags := data.scheduledAction(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Updates are rejected unless they carry the current eTag
+	scope := strings.TrimSuffix(data.Scope.ValueString(), "/")
+	current, err := r.ScheduledActionsClient.Get(ctx, scope, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(responseErrorDiagnostic("Error reading cost scheduled action", err))
+		return
+	}
+	scheduledAction.ETag = current.ETag
+
+	result, err := r.ScheduledActionsClient.CreateOrUpdate(ctx, scope, data.Name.ValueString(), scheduledAction)
+	if err != nil {
+		resp.Diagnostics.Append(responseErrorDiagnostic("Error updating cost scheduled action", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.refresh(ctx, result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CostScheduledActionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *CostScheduledActionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "deleting cost scheduled action resource")
+
+	scope := strings.TrimSuffix(data.Scope.ValueString(), "/")
+	if err := r.ScheduledActionsClient.Delete(ctx, scope, data.Name.ValueString()); err != nil {
+		resp.Diagnostics.Append(responseErrorDiagnostic("Error deleting cost scheduled action", err))
+		return
+	}
+}
+
+// ImportState accepts the scheduled action ID, e.g.
+// /subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.CostManagement/scheduledActions/example.
+func (r *CostScheduledActionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	scope, name, err := parseCostResourceID(req.ID, "scheduledActions")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("scope"), scope)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
+
+// scheduledAction builds the scheduled action described by the model.
+func (m *CostScheduledActionResourceModel) scheduledAction(ctx context.Context) (subscriptionSettings.ScheduledAction, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	scheduledAction := subscriptionSettings.ScheduledAction{
+		Kind: subscriptionSettings.ScheduledActionKind(m.Kind.ValueString()),
+		Properties: subscriptionSettings.ScheduledActionProperties{
+			DisplayName:       m.DisplayName.ValueString(),
+			Status:            subscriptionSettings.ScheduledActionStatus(m.Status.ValueString()),
+			ViewID:            m.ViewID.ValueString(),
+			NotificationEmail: m.NotificationEmail.ValueString(),
+			Scope:             strings.TrimSuffix(m.Scope.ValueString(), "/"),
+		},
+	}
+
+	var notification costScheduledActionNotificationModel
+	diags.Append(m.Notification.As(ctx, &notification, basetypes.ObjectAsOptions{})...)
+	scheduledAction.Properties.Notification = subscriptionSettings.NotificationProperties{
+		Subject:        notification.Subject.ValueString(),
+		Message:        notification.Message.ValueString(),
+		Language:       notification.Language.ValueString(),
+		RegionalFormat: notification.RegionalFormat.ValueString(),
+	}
+	diags.Append(notification.To.ElementsAs(ctx, &scheduledAction.Properties.Notification.To, false)...)
+
+	var schedule costScheduledActionScheduleModel
+	diags.Append(m.Schedule.As(ctx, &schedule, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return scheduledAction, diags
+	}
+	startDate, err := parseCostTime(schedule.StartDate)
+	if err != nil {
+		diags.AddAttributeError(path.Root("schedule").AtName("start_date"), "Invalid schedule", err.Error())
+	}
+	endDate, err := parseCostTime(schedule.EndDate)
+	if err != nil {
+		diags.AddAttributeError(path.Root("schedule").AtName("end_date"), "Invalid schedule", err.Error())
+	}
+	scheduledAction.Properties.Schedule = subscriptionSettings.ScheduleProperties{
+		Frequency: subscriptionSettings.ScheduleFrequency(schedule.Frequency.ValueString()),
+		StartDate: startDate,
+		EndDate:   endDate,
+	}
+	if !schedule.HourOfDay.IsNull() && !schedule.HourOfDay.IsUnknown() {
+		hourOfDay := int32(schedule.HourOfDay.ValueInt64())
+		scheduledAction.Properties.Schedule.HourOfDay = &hourOfDay
+	}
+	if !schedule.DayOfMonth.IsNull() && !schedule.DayOfMonth.IsUnknown() {
+		dayOfMonth := int32(schedule.DayOfMonth.ValueInt64())
+		scheduledAction.Properties.Schedule.DayOfMonth = &dayOfMonth
+	}
+	if !schedule.DaysOfWeek.IsNull() && !schedule.DaysOfWeek.IsUnknown() {
+		diags.Append(schedule.DaysOfWeek.ElementsAs(ctx, &scheduledAction.Properties.Schedule.DaysOfWeek, false)...)
+	}
+	if !schedule.WeeksOfMonth.IsNull() && !schedule.WeeksOfMonth.IsUnknown() {
+		diags.Append(schedule.WeeksOfMonth.ElementsAs(ctx, &scheduledAction.Properties.Schedule.WeeksOfMonth, false)...)
+	}
+
+	if !m.FileFormats.IsNull() && !m.FileFormats.IsUnknown() {
+		fileDestination := &subscriptionSettings.FileDestination{}
+		diags.Append(m.FileFormats.ElementsAs(ctx, &fileDestination.FileFormats, false)...)
+		scheduledAction.Properties.FileDestination = fileDestination
+	}
+
+	return scheduledAction, diags
+}
+
+// refresh updates the model from scheduledAction.
+func (m *CostScheduledActionResourceModel) refresh(ctx context.Context, scheduledAction subscriptionSettings.ScheduledAction) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var d diag.Diagnostics
+
+	properties := scheduledAction.Properties
+
+	if scheduledAction.ID != "" {
+		m.ID = types.StringValue(scheduledAction.ID)
+	}
+	m.Kind = types.StringValue(string(scheduledAction.Kind))
+	if scheduledAction.Kind == "" {
+		m.Kind = types.StringValue(string(subscriptionSettings.ScheduledActionKindEmail))
+	}
+	m.DisplayName = types.StringValue(properties.DisplayName)
+	m.Status = types.StringValue(string(properties.Status))
+	m.ViewID = types.StringValue(properties.ViewID)
+	m.NotificationEmail = stringValueOrNull(properties.NotificationEmail)
+
+	to, d := types.ListValueFrom(ctx, types.StringType, properties.Notification.To)
+	diags.Append(d...)
+	m.Notification, d = types.ObjectValueFrom(ctx, costScheduledActionNotificationAttrTypes, costScheduledActionNotificationModel{
+		To:             to,
+		Subject:        types.StringValue(properties.Notification.Subject),
+		Message:        stringValueOrNull(properties.Notification.Message),
+		Language:       stringValueOrNull(properties.Notification.Language),
+		RegionalFormat: stringValueOrNull(properties.Notification.RegionalFormat),
+	})
+	diags.Append(d...)
+
+	var currentSchedule costScheduledActionScheduleModel
+	if !m.Schedule.IsNull() && !m.Schedule.IsUnknown() {
+		diags.Append(m.Schedule.As(ctx, &currentSchedule, basetypes.ObjectAsOptions{})...)
+	}
+	schedule := properties.Schedule
+	value := costScheduledActionScheduleModel{
+		Frequency:    types.StringValue(string(schedule.Frequency)),
+		HourOfDay:    types.Int64Null(),
+		DayOfMonth:   types.Int64Null(),
+		DaysOfWeek:   types.SetNull(types.StringType),
+		WeeksOfMonth: types.SetNull(types.StringType),
+		StartDate:    costTimeValue(currentSchedule.StartDate, &schedule.StartDate),
+		EndDate:      costTimeValue(currentSchedule.EndDate, &schedule.EndDate),
+	}
+	if schedule.HourOfDay != nil {
+		value.HourOfDay = types.Int64Value(int64(*schedule.HourOfDay))
+	}
+	if schedule.DayOfMonth != nil {
+		value.DayOfMonth = types.Int64Value(int64(*schedule.DayOfMonth))
+	}
+	if len(schedule.DaysOfWeek) > 0 {
+		value.DaysOfWeek, d = types.SetValueFrom(ctx, types.StringType, schedule.DaysOfWeek)
+		diags.Append(d...)
+	}
+	if len(schedule.WeeksOfMonth) > 0 {
+		value.WeeksOfMonth, d = types.SetValueFrom(ctx, types.StringType, schedule.WeeksOfMonth)
+		diags.Append(d...)
+	}
+	m.Schedule, d = types.ObjectValueFrom(ctx, costScheduledActionScheduleAttrTypes, value)
+	diags.Append(d...)
+
+	m.FileFormats = types.SetNull(types.StringType)
+	if properties.FileDestination != nil && len(properties.FileDestination.FileFormats) > 0 {
+		m.FileFormats, d = types.SetValueFrom(ctx, types.StringType, properties.FileDestination.FileFormats)
+		diags.Append(d...)
+	}
+
+	return diags
+}
@@ -0,0 +1,180 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccCostScheduledActionResource(t *testing.T) {
+	skipWithoutTerraform(t)
+	fake := newFakeARM(t)
+	id := fakeSubscriptionScope + "/providers/Microsoft.CostManagement/scheduledActions/weekly-costs"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fake.providerFactories(),
+		CheckDestroy: func(*terraform.State) error {
+			if fake.getCostResourceAt(id) != nil {
+				return fmt.Errorf("expected scheduled action %s to be deleted", id)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + testAccCostScheduledActionResourceConfig(`frequency    = "Weekly"
+    days_of_week = ["Monday"]
+    hour_of_day  = 0`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("azurex_cost_scheduled_action.test", "id", id),
+					resource.TestCheckResourceAttr("azurex_cost_scheduled_action.test", "kind", "Email"),
+					resource.TestCheckResourceAttr("azurex_cost_scheduled_action.test", "status", "Enabled"),
+					resource.TestCheckResourceAttr("azurex_cost_scheduled_action.test", "schedule.hour_of_day", "0"),
+					resource.TestCheckResourceAttr("azurex_cost_scheduled_action.test", "schedule.days_of_week.#", "1"),
+					resource.TestCheckResourceAttr("azurex_cost_scheduled_action.test", "file_formats.#", "1"),
+				),
+			},
+			{
+				Config: fake.providerConfig() + testAccCostScheduledActionResourceConfig(`frequency      = "Monthly"
+    days_of_week   = ["Friday"]
+    weeks_of_month = ["Last"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("azurex_cost_scheduled_action.test", "schedule.frequency", "Monthly"),
+					resource.TestCheckTypeSetElemAttr("azurex_cost_scheduled_action.test", "schedule.weeks_of_month.*", "Last"),
+					resource.TestCheckNoResourceAttr("azurex_cost_scheduled_action.test", "schedule.hour_of_day"),
+				),
+			},
+			{
+				ResourceName:      "azurex_cost_scheduled_action.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccCostScheduledActionResource_insightAlert(t *testing.T) {
+	skipWithoutTerraform(t)
+	fake := newFakeARM(t)
+
+	config := func(scope string, frequency string) string {
+		return fmt.Sprintf(`
+resource "azurex_cost_scheduled_action" "test" {
+  name         = "anomalies"
+  scope        = %[1]q
+  kind         = "InsightAlert"
+  display_name = "Daily anomalies"
+  view_id      = "%[1]s/providers/Microsoft.CostManagement/views/ms:DailyAnomalyByResourceGroup"
+
+  notification = {
+    to      = ["finops@example.com"]
+    subject = "Cost anomaly detected"
+  }
+
+  schedule = {
+    frequency  = %[2]q
+    start_date = "2030-01-01T00:00:00Z"
+    end_date   = "2031-01-01T00:00:00Z"
+  }
+}
+`, scope, frequency)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fake.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config:      fake.providerConfig() + config(fakeSubscriptionScope+"/resourceGroups/example", "Daily"),
+				ExpectError: regexp.MustCompile(`Insight alerts can only be created at a subscription scope`),
+			},
+			{
+				Config:      fake.providerConfig() + config(fakeSubscriptionScope, "Weekly"),
+				ExpectError: regexp.MustCompile(`Insight alerts only support the Daily frequency`),
+			},
+			{
+				Config: fake.providerConfig() + config(fakeSubscriptionScope, "Daily"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("azurex_cost_scheduled_action.test", "kind", "InsightAlert"),
+					resource.TestCheckResourceAttr("azurex_cost_scheduled_action.test", "schedule.frequency", "Daily"),
+					resource.TestCheckNoResourceAttr("azurex_cost_scheduled_action.test", "file_formats"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCostScheduledActionResource_scheduleValidation(t *testing.T) {
+	skipWithoutTerraform(t)
+	fake := newFakeARM(t)
+
+	for name, tc := range map[string]struct {
+		schedule string
+		err      string
+	}{
+		"daily with days": {
+			schedule: `frequency = "Daily"
+    days_of_week = ["Monday"]`,
+			err: `days_of_week attribute can not be set when frequency is Daily`,
+		},
+		"weekly without days": {
+			schedule: `frequency = "Weekly"`,
+			err:      `days_of_week attribute must be set when frequency is Weekly`,
+		},
+		"monthly without day": {
+			schedule: `frequency = "Monthly"`,
+			err:      `Either day_of_month, or days_of_week and weeks_of_month`,
+		},
+		"monthly with both": {
+			schedule: `frequency = "Monthly"
+    day_of_month = 1
+    weeks_of_month = ["First"]`,
+			err: `day_of_month attribute conflicts`,
+		},
+		"unknown day": {
+			schedule: `frequency = "Weekly"
+    days_of_week = ["Someday"]`,
+			err: `value must be one of`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: fake.providerFactories(),
+				Steps: []resource.TestStep{
+					{
+						Config:      fake.providerConfig() + testAccCostScheduledActionResourceConfig(tc.schedule),
+						ExpectError: regexp.MustCompile(tc.err),
+					},
+				},
+			})
+		})
+	}
+}
+
+func testAccCostScheduledActionResourceConfig(schedule string) string {
+	return fmt.Sprintf(`
+resource "azurex_cost_scheduled_action" "test" {
+  name         = "weekly-costs"
+  scope        = "/subscriptions/00000000-0000-0000-0000-000000000000"
+  display_name = "Weekly costs by service"
+  view_id      = "/providers/Microsoft.CostManagement/views/ms:CostByService"
+  file_formats = ["Csv"]
+
+  notification = {
+    to      = ["finops@example.com", "platform@example.com"]
+    subject = "Weekly costs"
+    message = "Costs of the platform subscription by service."
+  }
+
+  schedule = {
+    %s
+    start_date = "2030-01-01T00:00:00Z"
+    end_date   = "2031-01-01T00:00:00Z"
+  }
+}
+`, schedule)
+}
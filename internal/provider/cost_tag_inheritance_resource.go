@@ -0,0 +1,297 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	subscriptionSettings "github.com/ekristen/terraform-provider-azurex/internal/azure/subscriptions"
+)
+
+// tagInheritanceSettingsPath is appended to a scope to form the settings ID.
+const tagInheritanceSettingsPath = "/providers/Microsoft.CostManagement/settings/taginheritance"
+
+// tagInheritanceScopePattern matches the scopes Cost Management accepts the
+// taginheritance setting on: subscriptions, billing accounts (EA) and billing
+// profiles (MCA). The settings API has no management group scope, tag
+// inheritance for a management group is enabled on its subscriptions.
+var tagInheritanceScopePattern = regexp.MustCompile(`(?i)^/(subscriptions/[^/]+|providers/Microsoft\.Billing/billingAccounts/[^/]+(/billingProfiles/[^/]+)?)/?$`)
+
+// tagInheritanceDisableChecks and tagInheritanceDisableInterval bound how long
+// disableTagInheritance waits for the setting to disappear.
+var (
+	tagInheritanceDisableChecks   = 5
+	tagInheritanceDisableInterval = 2 * time.Second
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &CostTagInheritanceResource{}
+var _ resource.ResourceWithImportState = &CostTagInheritanceResource{}
+
+func NewCostTagInheritanceResource() resource.Resource {
+	return &CostTagInheritanceResource{}
+}
+
+// CostTagInheritanceResource defines the resource implementation.
+type CostTagInheritanceResource struct {
+	SettingsClient *subscriptionSettings.SettingsClient
+}
+
+// CostTagInheritanceResourceModel describes the resource data model.
+type CostTagInheritanceResourceModel struct {
+	ID                  types.String `tfsdk:"id"`
+	Scope               types.String `tfsdk:"scope"`
+	PreferContainerTags types.Bool   `tfsdk:"prefer_container_tags"`
+}
+
+func (r *CostTagInheritanceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cost_tag_inheritance"
+}
+
+func (r *CostTagInheritanceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Cost Management tag inheritance, applies subscription and resource group tags to the usage records of child resources.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the tag inheritance setting",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"scope": schema.StringAttribute{
+				MarkdownDescription: "Scope to enable tag inheritance on, either a subscription (`/subscriptions/{subscriptionId}`), a billing account (`/providers/Microsoft.Billing/billingAccounts/{billingAccountId}`) or a billing profile (`/providers/Microsoft.Billing/billingAccounts/{billingAccountId}/billingProfiles/{billingProfileId}`). Management groups are not supported by Cost Management, enable tag inheritance on their subscriptions instead. Changing this forces a new resource to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(tagInheritanceScopePattern, "must be a subscription, billing account or billing profile scope"),
+				},
+			},
+			"prefer_container_tags": schema.BoolAttribute{
+				MarkdownDescription: "Prefer subscription/resource group tags over resource tags when there's a conflict. Defaults to `false`. " +
+					"When the scope is a subscription also managed by `azurex_subscription_tags`, set `inherit_tags = false` there, it enables tag inheritance with `prefer_containers = true` by default and would overwrite this setting.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *CostTagInheritanceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(AzurexContext)
+	if !ok {
+		resp.Diagnostics.AddError("unable to obtain provider data", "provider data not available")
+		return
+	}
+
+	settingsClient, err := subscriptionSettings.NewSettingsClient(data.SubscriptionID, data.IdentityCreds, data.ClientOptions)
+	if err != nil {
+		resp.Diagnostics.AddError("unable to configure settings client", fmt.Sprintf("got: %s", err.Error()))
+		return
+	}
+	r.SettingsClient = settingsClient
+}
+
+func (r *CostTagInheritanceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *CostTagInheritanceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "creating cost tag inheritance resource")
+
+	settingsClient, err := r.settingsClient(data.Scope.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("scope"), "Invalid scope", err.Error())
+		return
+	}
+
+	tagInheritance, err := settingsClient.EnableTagInheritance(ctx, data.PreferContainerTags.ValueBool())
+	if err != nil {
+		resp.Diagnostics.Append(responseErrorDiagnostic("Error enabling tag inheritance", err))
+		return
+	}
+
+	data.ID = types.StringValue(tagInheritance.Id)
+	if tagInheritance.Id == "" {
+		data.ID = types.StringValue(data.Scope.ValueString() + tagInheritanceSettingsPath)
+	}
+	data.PreferContainerTags = types.BoolValue(tagInheritance.Properties.PreferContainerTags)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CostTagInheritanceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *CostTagInheritanceResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settingsClient, err := r.settingsClient(data.Scope.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("scope"), "Invalid scope", err.Error())
+		return
+	}
+
+	tagInheritance, err := settingsClient.GetTagInheritance(ctx)
+	if err != nil && !subscriptionSettings.IsNotFound(err) {
+		resp.Diagnostics.Append(responseErrorDiagnostic("Error getting tag inheritance settings", err))
+		return
+	}
+
+	if err != nil || tagInheritance.Id == "" {
+		tflog.Debug(ctx, fmt.Sprintf("tag inheritance is not enabled on %s, removing from state", data.Scope.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.ID = types.StringValue(tagInheritance.Id)
+	data.PreferContainerTags = types.BoolValue(tagInheritance.Properties.PreferContainerTags)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CostTagInheritanceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *CostTagInheritanceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updating cost tag inheritance resource")
+
+	settingsClient, err := r.settingsClient(data.Scope.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("scope"), "Invalid scope", err.Error())
+		return
+	}
+
+	tagInheritance, err := settingsClient.EnableTagInheritance(ctx, data.PreferContainerTags.ValueBool())
+	if err != nil {
+		resp.Diagnostics.Append(responseErrorDiagnostic("Error updating tag inheritance settings", err))
+		return
+	}
+
+	data.PreferContainerTags = types.BoolValue(tagInheritance.Properties.PreferContainerTags)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CostTagInheritanceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *CostTagInheritanceResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "deleting cost tag inheritance resource")
+
+	settingsClient, err := r.settingsClient(data.Scope.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("scope"), "Invalid scope", err.Error())
+		return
+	}
+
+	if err := disableTagInheritance(ctx, settingsClient); err != nil {
+		resp.Diagnostics.Append(responseErrorDiagnostic("Error disabling tag inheritance", err))
+		return
+	}
+}
+
+// ImportState accepts the ID of the tag inheritance setting, e.g.
+// /subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.CostManagement/settings/taginheritance.
+func (r *CostTagInheritanceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	scope, ok := cutSuffixFold(req.ID, tagInheritanceSettingsPath)
+	if !ok || scope == "" {
+		resp.Diagnostics.AddError("Invalid import ID",
+			fmt.Sprintf("Expected {scope}%s, got %q.", tagInheritanceSettingsPath, req.ID))
+		return
+	}
+	if !tagInheritanceScopePattern.MatchString(scope) {
+		resp.Diagnostics.AddError("Invalid import ID",
+			fmt.Sprintf("Expected a subscription, billing account or billing profile scope, got %q.", scope))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("scope"), scope)...)
+}
+
+// cutSuffixFold is strings.CutSuffix ignoring case, ARM IDs are
+// case-insensitive. The rest of s keeps its casing.
+func cutSuffixFold(s string, suffix string) (string, bool) {
+	if len(s) < len(suffix) || !strings.EqualFold(s[len(s)-len(suffix):], suffix) {
+		return s, false
+	}
+	return s[:len(s)-len(suffix)], true
+}
+
+// settingsClient returns a SettingsClient targeting scope.
+func (r *CostTagInheritanceResource) settingsClient(scope string) (*subscriptionSettings.SettingsClient, error) {
+	if !tagInheritanceScopePattern.MatchString(scope) {
+		return nil, fmt.Errorf("expected a subscription, billing account or billing profile scope, got %q", scope)
+	}
+	return r.SettingsClient.WithScope(scope), nil
+}
+
+// disableTagInheritance deletes the tag inheritance setting and re-reads it
+// until Azure no longer reports it, so a destroy or update that reports
+// success really has turned inheritance off.
+func disableTagInheritance(ctx context.Context, client *subscriptionSettings.SettingsClient) error {
+	if err := client.DisableTagInheritance(ctx); err != nil {
+		return err
+	}
+
+	for i := 0; ; i++ {
+		tagInheritance, err := client.GetTagInheritance(ctx)
+		if subscriptionSettings.IsNotFound(err) || (err == nil && tagInheritance.Id == "") {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("verifying tag inheritance is disabled: %w", err)
+		}
+		if i+1 >= tagInheritanceDisableChecks {
+			return fmt.Errorf("tag inheritance is still enabled on %s after deleting the setting", tagInheritance.Id)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(tagInheritanceDisableInterval):
+		}
+	}
+}
@@ -0,0 +1,294 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+
+	subscriptionSettings "github.com/ekristen/terraform-provider-azurex/internal/azure/subscriptions"
+)
+
+func TestAccCostTagInheritanceResource(t *testing.T) {
+	skipWithoutTerraform(t)
+	fake := newFakeARM(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fake.providerFactories(),
+		CheckDestroy:             fake.checkTagInheritance(fakeSubscriptionScope, false, false),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + testAccCostTagInheritanceResourceConfig(fakeSubscriptionScope, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("azurex_cost_tag_inheritance.test", "id", fakeSubscriptionScope+tagInheritanceSettingsPath),
+					resource.TestCheckResourceAttr("azurex_cost_tag_inheritance.test", "prefer_container_tags", "false"),
+					fake.checkTagInheritance(fakeSubscriptionScope, true, false),
+				),
+			},
+			{
+				Config: fake.providerConfig() + testAccCostTagInheritanceResourceConfig(fakeSubscriptionScope, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("azurex_cost_tag_inheritance.test", "prefer_container_tags", "true"),
+					fake.checkTagInheritance(fakeSubscriptionScope, true, true),
+				),
+			},
+			{
+				ResourceName:      "azurex_cost_tag_inheritance.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Drift, the setting is deleted outside of Terraform.
+				PreConfig: func() {
+					fake.setTagInheritance(fakeSubscriptionScope, false, false)
+				},
+				Config: fake.providerConfig() + testAccCostTagInheritanceResourceConfig(fakeSubscriptionScope, true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("azurex_cost_tag_inheritance.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: fake.checkTagInheritance(fakeSubscriptionScope, true, true),
+			},
+		},
+	})
+}
+
+func TestAccCostTagInheritanceResource_billingProfile(t *testing.T) {
+	skipWithoutTerraform(t)
+	fake := newFakeARM(t)
+	scope := "/providers/Microsoft.Billing/billingAccounts/1234:5678_2019-05-31/billingProfiles/ABCD-EFGH"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fake.providerFactories(),
+		CheckDestroy:             fake.checkTagInheritance(scope, false, false),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + testAccCostTagInheritanceResourceConfig(scope, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("azurex_cost_tag_inheritance.test", "id", scope+tagInheritanceSettingsPath),
+					fake.checkTagInheritance(scope, true, true),
+				),
+			},
+		},
+	})
+}
+
+func testAccCostTagInheritanceResourceConfig(scope string, preferContainerTags bool) string {
+	return fmt.Sprintf(`
+resource "azurex_cost_tag_inheritance" "test" {
+  scope                 = %q
+  prefer_container_tags = %t
+}
+`, scope, preferContainerTags)
+}
+
+func TestTagInheritanceScopePattern(t *testing.T) {
+	cases := map[string]bool{
+		"/subscriptions/00000000-0000-0000-0000-000000000000":                             true,
+		"/subscriptions/00000000-0000-0000-0000-000000000000/":                            true,
+		"/providers/Microsoft.Billing/billingAccounts/12345678":                           true,
+		"/providers/microsoft.billing/billingaccounts/1234:5678_2019-05-31":               true,
+		"/providers/Microsoft.Billing/billingAccounts/1234:5678/billingProfiles/ABCD-EFG": true,
+		"":                 false,
+		"/subscriptions/":  false,
+		"subscriptions/00": false,
+		"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example":     false,
+		"/providers/Microsoft.Management/managementGroups/example":                       false,
+		"/providers/Microsoft.Billing/billingAccounts/1234/billingProfiles/ABCD/extra/x": false,
+	}
+
+	for scope, want := range cases {
+		t.Run(scope, func(t *testing.T) {
+			if got := tagInheritanceScopePattern.MatchString(scope); got != want {
+				t.Fatalf("expected match %t for %q, got %t", want, scope, got)
+			}
+		})
+	}
+}
+
+func TestCutSuffixFold(t *testing.T) {
+	scope, ok := cutSuffixFold("/providers/Microsoft.Billing/billingAccounts/AbC/PROVIDERS/microsoft.costmanagement/Settings/TagInheritance", tagInheritanceSettingsPath)
+	if !ok || scope != "/providers/Microsoft.Billing/billingAccounts/AbC" {
+		t.Fatalf("expected the scope with its casing, got %q (%t)", scope, ok)
+	}
+
+	for _, id := range []string{"", "/settings/taginheritance", "/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.CostManagement/views/x"} {
+		if _, ok := cutSuffixFold(id, tagInheritanceSettingsPath); ok {
+			t.Errorf("expected %q not to end with the settings path", id)
+		}
+	}
+}
+
+func TestCostTagInheritanceResource_enableDisable(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeARM(t)
+
+	r := &CostTagInheritanceResource{}
+	var configureResp fwresource.ConfigureResponse
+	r.Configure(ctx, fwresource.ConfigureRequest{ProviderData: fake.providerData(fakeSubscriptionID)}, &configureResp)
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	plan := func(preferContainerTags bool) tfsdk.Plan {
+		t.Helper()
+		plan := tfsdk.Plan{Schema: schemaResp.Schema}
+		if diags := plan.Set(ctx, &CostTagInheritanceResourceModel{
+			ID:                  types.StringUnknown(),
+			Scope:               types.StringValue(fakeSubscriptionScope),
+			PreferContainerTags: types.BoolValue(preferContainerTags),
+		}); diags.HasError() {
+			t.Fatalf("building plan: %v", diags)
+		}
+		return plan
+	}
+
+	created := fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan(true)}, &created)
+	if created.Diagnostics.HasError() {
+		t.Fatalf("creating resource: %v", created.Diagnostics)
+	}
+	var got CostTagInheritanceResourceModel
+	created.Diagnostics.Append(created.State.Get(ctx, &got)...)
+	if got.ID.ValueString() != fakeSubscriptionScope+tagInheritanceSettingsPath {
+		t.Fatalf("unexpected ID %q", got.ID.ValueString())
+	}
+	if err := fake.checkTagInheritance(fakeSubscriptionScope, true, true)(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	updated := fwresource.UpdateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan(false), State: created.State}, &updated)
+	if updated.Diagnostics.HasError() {
+		t.Fatalf("updating resource: %v", updated.Diagnostics)
+	}
+	if err := fake.checkTagInheritance(fakeSubscriptionScope, true, false)(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	var deleted fwresource.DeleteResponse
+	r.Delete(ctx, fwresource.DeleteRequest{State: updated.State}, &deleted)
+	if deleted.Diagnostics.HasError() {
+		t.Fatalf("deleting resource: %v", deleted.Diagnostics)
+	}
+	if err := fake.checkTagInheritance(fakeSubscriptionScope, false, false)(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// A setting deleted outside of Terraform is removed from state.
+	read := fwresource.ReadResponse{State: updated.State}
+	r.Read(ctx, fwresource.ReadRequest{State: updated.State}, &read)
+	if read.Diagnostics.HasError() || !read.State.Raw.IsNull() {
+		t.Fatalf("expected the resource to be removed from state, got %v", read.Diagnostics)
+	}
+}
+
+func TestDisableTagInheritance(t *testing.T) {
+	interval := tagInheritanceDisableInterval
+	tagInheritanceDisableInterval = 0
+	t.Cleanup(func() { tagInheritanceDisableInterval = interval })
+
+	cases := map[string]struct {
+		// lag is how many reads still report the setting after the delete
+		lag       int
+		getStatus int
+		wantGets  int
+		wantErr   string
+	}{
+		"disabled at once": {
+			wantGets: 1,
+		},
+		"disabled after polling": {
+			lag:      2,
+			wantGets: 3,
+		},
+		"still enabled": {
+			lag:      tagInheritanceDisableChecks,
+			wantGets: tagInheritanceDisableChecks,
+			wantErr:  "still enabled",
+		},
+		"read failure": {
+			getStatus: http.StatusInternalServerError,
+			wantGets:  1,
+			wantErr:   "verifying tag inheritance is disabled",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			deletes, gets := 0, 0
+			client := newTestTagInheritanceClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.Method {
+				case http.MethodDelete:
+					deletes++
+					w.WriteHeader(http.StatusOK)
+				case http.MethodGet:
+					gets++
+					switch {
+					case tc.getStatus != 0:
+						w.WriteHeader(tc.getStatus)
+						_, _ = w.Write([]byte(`{"error": {"code": "InternalServerError", "message": "boom"}}`))
+					case gets <= tc.lag:
+						_, _ = fmt.Fprintf(w, `{"id": "%s%s", "properties": {"preferContainerTags": false}}`, fakeSubscriptionScope, tagInheritanceSettingsPath)
+					default:
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"error": {"code": "NotFound", "message": "Setting 'taginheritance' was not found."}}`))
+					}
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			})
+
+			err := disableTagInheritance(context.Background(), client)
+			if tc.wantErr == "" && err != nil {
+				t.Fatalf("disabling tag inheritance: %s", err)
+			}
+			if tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)) {
+				t.Fatalf("expected an error containing %q, got: %v", tc.wantErr, err)
+			}
+			if deletes != 1 || gets != tc.wantGets {
+				t.Fatalf("expected 1 delete and %d reads, got %d and %d", tc.wantGets, deletes, gets)
+			}
+		})
+	}
+}
+
+// newTestTagInheritanceClient returns a SettingsClient for the fake
+// subscription sending every request to handler.
+func newTestTagInheritanceClient(t *testing.T, handler http.HandlerFunc) *subscriptionSettings.SettingsClient {
+	t.Helper()
+
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := subscriptionSettings.NewSettingsClient(fakeSubscriptionID, staticCredential{token: "fake"}, &arm.ClientOptions{
+		ClientOptions: policy.ClientOptions{
+			Cloud: cloud.Configuration{
+				Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+					cloud.ResourceManager: {Endpoint: server.URL, Audience: server.URL},
+				},
+			},
+			Retry:     policy.RetryOptions{MaxRetries: -1},
+			Transport: server.Client(),
+		},
+	})
+	if err != nil {
+		t.Fatalf("creating settings client: %s", err)
+	}
+	return client
+}
@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateCostTimePeriod(t *testing.T) {
+	timePeriod := func(from string, to attr.Value) types.Object {
+		return types.ObjectValueMust(costExportTimePeriodAttrTypes, map[string]attr.Value{
+			"from": types.StringValue(from),
+			"to":   to,
+		})
+	}
+
+	for name, tc := range map[string]struct {
+		timeframe  string
+		timePeriod types.Object
+		wantError  string
+	}{
+		"custom":            {"Custom", timePeriod("2030-01-01T00:00:00Z", types.StringValue("2030-01-31T00:00:00Z")), ""},
+		"unknown end":       {"Custom", timePeriod("2030-01-31T00:00:00Z", types.StringUnknown()), ""},
+		"not custom":        {"MonthToDate", types.ObjectNull(costExportTimePeriodAttrTypes), ""},
+		"missing":           {"Custom", types.ObjectNull(costExportTimePeriodAttrTypes), "Missing time period"},
+		"unexpected":        {"MonthToDate", timePeriod("2030-01-01T00:00:00Z", types.StringValue("2030-01-31T00:00:00Z")), "Unexpected time period"},
+		"ends before":       {"Custom", timePeriod("2030-01-31T00:00:00Z", types.StringValue("2030-01-01T00:00:00Z")), "Invalid time period"},
+		"invalid timestamp": {"Custom", timePeriod("2030-01-01", types.StringValue("2030-01-31T00:00:00Z")), "Invalid time period"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			diags := validateCostTimePeriod(context.Background(), types.StringValue(tc.timeframe), tc.timePeriod)
+			if tc.wantError == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+				return
+			}
+			if len(diags.Errors()) != 1 || diags.Errors()[0].Summary() != tc.wantError {
+				t.Fatalf("expected a %q error, got %v", tc.wantError, diags)
+			}
+		})
+	}
+}
@@ -0,0 +1,711 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	subscriptionSettings "github.com/ekristen/terraform-provider-azurex/internal/azure/subscriptions"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &CostViewResource{}
+var _ resource.ResourceWithImportState = &CostViewResource{}
+var _ resource.ResourceWithValidateConfig = &CostViewResource{}
+
+func NewCostViewResource() resource.Resource {
+	return &CostViewResource{}
+}
+
+// CostViewResource defines the resource implementation.
+type CostViewResource struct {
+	ViewsClient *subscriptionSettings.ViewsClient
+}
+
+// CostViewResourceModel describes the resource data model.
+type CostViewResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Scope       types.String `tfsdk:"scope"`
+	DisplayName types.String `tfsdk:"display_name"`
+	Chart       types.String `tfsdk:"chart"`
+	Accumulated types.Bool   `tfsdk:"accumulated"`
+	Metric      types.String `tfsdk:"metric"`
+	Timeframe   types.String `tfsdk:"timeframe"`
+	TimePeriod  types.Object `tfsdk:"time_period"`
+	Granularity types.String `tfsdk:"granularity"`
+	Aggregation types.Map    `tfsdk:"aggregation"`
+	Grouping    types.List   `tfsdk:"grouping"`
+	Sorting     types.List   `tfsdk:"sorting"`
+	Columns     types.List   `tfsdk:"columns"`
+	Kpis        types.List   `tfsdk:"kpis"`
+	Pivots      types.List   `tfsdk:"pivots"`
+	Currency    types.String `tfsdk:"currency"`
+}
+
+// costViewAggregationModel is a single entry of aggregation.
+type costViewAggregationModel struct {
+	Column   types.String `tfsdk:"column"`
+	Function types.String `tfsdk:"function"`
+}
+
+var costViewAggregationAttrTypes = map[string]attr.Type{
+	"column":   types.StringType,
+	"function": types.StringType,
+}
+
+// costViewColumnModel is a single entry of grouping or pivots.
+type costViewColumnModel struct {
+	Type types.String `tfsdk:"type"`
+	Name types.String `tfsdk:"name"`
+}
+
+var costViewColumnAttrTypes = map[string]attr.Type{
+	"type": types.StringType,
+	"name": types.StringType,
+}
+
+// costViewSortingModel is a single entry of sorting.
+type costViewSortingModel struct {
+	Name      types.String `tfsdk:"name"`
+	Direction types.String `tfsdk:"direction"`
+}
+
+var costViewSortingAttrTypes = map[string]attr.Type{
+	"name":      types.StringType,
+	"direction": types.StringType,
+}
+
+// costViewKpiModel is a single entry of kpis.
+type costViewKpiModel struct {
+	Type    types.String `tfsdk:"type"`
+	ID      types.String `tfsdk:"id"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+}
+
+var costViewKpiAttrTypes = map[string]attr.Type{
+	"type":    types.StringType,
+	"id":      types.StringType,
+	"enabled": types.BoolType,
+}
+
+func (r *CostViewResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cost_view"
+}
+
+func (r *CostViewResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Cost Management view, a saved cost analysis shared with everyone who can read costs at its scope, or with the whole tenant when saved without one",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the view",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the view. Changing this forces a new resource to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope": schema.StringAttribute{
+				MarkdownDescription: "Scope the view is saved at, " + costScopeDescription + ". " +
+					"Views without a scope are shared with the whole tenant. Changing this forces a new resource to be created.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(costScopePattern, "must be a subscription, resource group, management group or billing scope"),
+				},
+			},
+			"display_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the view shown in cost analysis",
+				Required:            true,
+			},
+			"chart": schema.StringAttribute{
+				MarkdownDescription: "Chart of the view, one of `Area`, `Line`, `StackedColumn`, `GroupedColumn` or `Table`. Defaults to `Table`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(string(subscriptionSettings.ChartTypeTable)),
+				Validators: []validator.String{
+					stringvalidator.OneOf(enumValues(subscriptionSettings.PossibleChartTypeValues())...),
+				},
+			},
+			"accumulated": schema.BoolAttribute{
+				MarkdownDescription: "Show costs accumulated over the time frame. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"metric": schema.StringAttribute{
+				MarkdownDescription: "Costs shown by the view, one of `ActualCost`, `AmortizedCost` or `AHUB`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(enumValues(subscriptionSettings.PossibleMetricTypeValues())...),
+				},
+			},
+			"timeframe": schema.StringAttribute{
+				MarkdownDescription: "Time frame of the view, one of `WeekToDate`, `MonthToDate`, `YearToDate` or `Custom`. `Custom` requires `time_period`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(enumValues(subscriptionSettings.PossibleReportTimeframeTypeValues())...),
+				},
+			},
+			"time_period": schema.SingleNestedAttribute{
+				MarkdownDescription: "Date range of the view, only valid with the `Custom` timeframe",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"from": schema.StringAttribute{
+						MarkdownDescription: "Start of the date range, as an RFC 3339 timestamp",
+						Required:            true,
+					},
+					"to": schema.StringAttribute{
+						MarkdownDescription: "End of the date range, as an RFC 3339 timestamp",
+						Required:            true,
+					},
+				},
+			},
+			"granularity": schema.StringAttribute{
+				MarkdownDescription: "Granularity of the view, `Daily` or `Monthly`. Costs are aggregated over the time frame when unset.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(enumValues(subscriptionSettings.PossibleReportGranularityTypeValues())...),
+				},
+			},
+			"aggregation": schema.MapNestedAttribute{
+				MarkdownDescription: "Aggregated columns of the view keyed by their alias, e.g. `totalCost`, at most 2",
+				Optional:            true,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtMost(2),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"column": schema.StringAttribute{
+							MarkdownDescription: "Column to aggregate, e.g. `PreTaxCost`",
+							Required:            true,
+						},
+						"function": schema.StringAttribute{
+							MarkdownDescription: "Aggregation function, only `Sum` is supported. Defaults to `Sum`.",
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString(string(subscriptionSettings.FunctionTypeSum)),
+							Validators: []validator.String{
+								stringvalidator.OneOf(enumValues(subscriptionSettings.PossibleFunctionTypeValues())...),
+							},
+						},
+					},
+				},
+			},
+			"grouping": schema.ListNestedAttribute{
+				MarkdownDescription: "Columns the costs are grouped by, at most 2",
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(2),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: costViewColumnAttributes("group by", enumValues(subscriptionSettings.PossibleQueryColumnTypeValues())),
+				},
+			},
+			"sorting": schema.ListNestedAttribute{
+				MarkdownDescription: "Columns the costs are sorted by",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Column to sort by",
+							Required:            true,
+						},
+						"direction": schema.StringAttribute{
+							MarkdownDescription: "Sort direction, `Ascending` or `Descending`. Defaults to `Ascending`.",
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString(string(subscriptionSettings.ReportConfigSortingTypeAscending)),
+							Validators: []validator.String{
+								stringvalidator.OneOf(enumValues(subscriptionSettings.PossibleReportConfigSortingTypeValues())...),
+							},
+						},
+					},
+				},
+			},
+			"columns": schema.ListAttribute{
+				MarkdownDescription: "Columns of the view, ignored when `aggregation` and `grouping` are set",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"kpis": schema.ListNestedAttribute{
+				MarkdownDescription: "KPIs shown above the chart",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Type of the KPI, `Budget` or `Forecast`",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(enumValues(subscriptionSettings.PossibleKpiTypeValues())...),
+							},
+						},
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Resource ID of the budget a `Budget` KPI shows",
+							Optional:            true,
+						},
+						"enabled": schema.BoolAttribute{
+							MarkdownDescription: "Show the KPI. Defaults to `true`.",
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(true),
+						},
+					},
+				},
+			},
+			"pivots": schema.ListNestedAttribute{
+				MarkdownDescription: "Breakdowns shown below the chart, at most 3",
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(3),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: costViewColumnAttributes("break the costs down by", enumValues(subscriptionSettings.PossiblePivotTypeValues())),
+				},
+			},
+			"currency": schema.StringAttribute{
+				MarkdownDescription: "Currency the view shows costs in",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// costViewColumnAttributes returns the attributes of a column to verb, used
+// by grouping and pivots.
+func costViewColumnAttributes(verb string, columnTypes []string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"type": schema.StringAttribute{
+			MarkdownDescription: "Type of the column, `Dimension` or `TagKey`",
+			Required:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf(columnTypes...),
+			},
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("Dimension, e.g. `ResourceGroupName`, or tag key to %s", verb),
+			Required:            true,
+		},
+	}
+}
+
+func (r *CostViewResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data CostViewResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateCostTimePeriod(ctx, data.Timeframe, data.TimePeriod)...)
+}
+
+func (r *CostViewResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(AzurexContext)
+	if !ok {
+		resp.Diagnostics.AddError("unable to obtain provider data", "provider data not available")
+		return
+	}
+
+	viewsClient, err := subscriptionSettings.NewViewsClient(data.IdentityCreds, data.ClientOptions)
+	if err != nil {
+		resp.Diagnostics.AddError("unable to configure views client", fmt.Sprintf("got: %s", err.Error()))
+		return
+	}
+	r.ViewsClient = viewsClient
+}
+
+func (r *CostViewResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *CostViewResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "creating cost view resource")
+
+	view, diags := data.view(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	scope := strings.TrimSuffix(data.Scope.ValueString(), "/")
+	result, err := r.ViewsClient.CreateOrUpdate(ctx, scope, data.Name.ValueString(), view)
+	if err != nil {
+		resp.Diagnostics.Append(responseErrorDiagnostic("Error creating cost view", err))
+		return
+	}
+
+	data.ID = types.StringValue(costResourceID(scope, "views", data.Name.ValueString()))
+
+	resp.Diagnostics.Append(data.refresh(ctx, result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CostViewResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *CostViewResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	scope := strings.TrimSuffix(data.Scope.ValueString(), "/")
+	view, err := r.ViewsClient.Get(ctx, scope, data.Name.ValueString())
+	if subscriptionSettings.IsNotFound(err) {
+		tflog.Debug(ctx, fmt.Sprintf("cost view %s no longer exists, removing from state", data.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(responseErrorDiagnostic("Error reading cost view", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.refresh(ctx, view)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CostViewResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *CostViewResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updating cost view resource")
+
+	view, diags := data.view(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Updates are rejected unless they carry the current eTag
+	scope := strings.TrimSuffix(data.Scope.ValueString(), "/")
+	current, err := r.ViewsClient.Get(ctx, scope, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(responseErrorDiagnostic("Error reading cost view", err))
+		return
+	}
+	view.ETag = current.ETag
+
+	result, err := r.ViewsClient.CreateOrUpdate(ctx, scope, data.Name.ValueString(), view)
+	if err != nil {
+		resp.Diagnostics.Append(responseErrorDiagnostic("Error updating cost view", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.refresh(ctx, result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CostViewResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *CostViewResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "deleting cost view resource")
+
+	scope := strings.TrimSuffix(data.Scope.ValueString(), "/")
+	if err := r.ViewsClient.Delete(ctx, scope, data.Name.ValueString()); err != nil {
+		resp.Diagnostics.Append(responseErrorDiagnostic("Error deleting cost view", err))
+		return
+	}
+}
+
+// ImportState accepts the view ID, e.g.
+// /subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.CostManagement/views/example,
+// or /providers/Microsoft.CostManagement/views/example for a view shared with the whole tenant.
+func (r *CostViewResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	scope := ""
+	name, shared := cutPrefixFold(req.ID, costResourceID("", "views", ""))
+	if !shared || name == "" || strings.Contains(name, "/") {
+		var err error
+		if scope, name, err = parseCostResourceID(req.ID, "views"); err != nil {
+			resp.Diagnostics.AddError("Invalid import ID", err.Error())
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("scope"), stringValueOrNull(scope))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
+
+// cutPrefixFold is strings.CutPrefix ignoring case, ARM IDs are case-insensitive.
+func cutPrefixFold(s string, prefix string) (string, bool) {
+	if len(s) < len(prefix) || !strings.EqualFold(s[:len(prefix)], prefix) {
+		return s, false
+	}
+	return s[len(prefix):], true
+}
+
+// view builds the view described by the model.
+func (m *CostViewResourceModel) view(ctx context.Context) (subscriptionSettings.View, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	accumulated := subscriptionSettings.AccumulatedTypeFalse
+	if m.Accumulated.ValueBool() {
+		accumulated = subscriptionSettings.AccumulatedTypeTrue
+	}
+
+	query := &subscriptionSettings.ReportConfigDefinition{
+		Type:      subscriptionSettings.ReportTypeUsage,
+		Timeframe: subscriptionSettings.ReportTimeframeType(m.Timeframe.ValueString()),
+		DataSet: &subscriptionSettings.ReportConfigDataset{
+			Granularity: subscriptionSettings.ReportGranularityType(m.Granularity.ValueString()),
+		},
+	}
+	view := subscriptionSettings.View{
+		Properties: subscriptionSettings.ViewProperties{
+			DisplayName: m.DisplayName.ValueString(),
+			Scope:       strings.TrimSuffix(m.Scope.ValueString(), "/"),
+			Chart:       subscriptionSettings.ChartType(m.Chart.ValueString()),
+			Accumulated: accumulated,
+			Metric:      subscriptionSettings.MetricType(m.Metric.ValueString()),
+			Query:       query,
+		},
+	}
+
+	if !m.TimePeriod.IsNull() && !m.TimePeriod.IsUnknown() {
+		from, to, d := costTimePeriod(ctx, m.TimePeriod)
+		diags.Append(d...)
+		query.TimePeriod = &subscriptionSettings.ReportConfigTimePeriod{From: from, To: to}
+	}
+
+	if !m.Aggregation.IsNull() && !m.Aggregation.IsUnknown() {
+		var aggregation map[string]costViewAggregationModel
+		diags.Append(m.Aggregation.ElementsAs(ctx, &aggregation, false)...)
+		query.DataSet.Aggregation = make(map[string]subscriptionSettings.ReportConfigAggregation, len(aggregation))
+		for alias, a := range aggregation {
+			query.DataSet.Aggregation[alias] = subscriptionSettings.ReportConfigAggregation{
+				Name:     a.Column.ValueString(),
+				Function: subscriptionSettings.FunctionType(a.Function.ValueString()),
+			}
+		}
+	}
+
+	if !m.Grouping.IsNull() && !m.Grouping.IsUnknown() {
+		var grouping []costViewColumnModel
+		diags.Append(m.Grouping.ElementsAs(ctx, &grouping, false)...)
+		for _, g := range grouping {
+			query.DataSet.Grouping = append(query.DataSet.Grouping, subscriptionSettings.ReportConfigGrouping{
+				Type: subscriptionSettings.QueryColumnType(g.Type.ValueString()),
+				Name: g.Name.ValueString(),
+			})
+		}
+	}
+
+	if !m.Sorting.IsNull() && !m.Sorting.IsUnknown() {
+		var sorting []costViewSortingModel
+		diags.Append(m.Sorting.ElementsAs(ctx, &sorting, false)...)
+		for _, s := range sorting {
+			query.DataSet.Sorting = append(query.DataSet.Sorting, subscriptionSettings.ReportConfigSorting{
+				Name:      s.Name.ValueString(),
+				Direction: subscriptionSettings.ReportConfigSortingType(s.Direction.ValueString()),
+			})
+		}
+	}
+
+	if !m.Columns.IsNull() && !m.Columns.IsUnknown() {
+		var columns []string
+		diags.Append(m.Columns.ElementsAs(ctx, &columns, false)...)
+		query.DataSet.Configuration = &subscriptionSettings.ReportConfigDatasetConfiguration{Columns: columns}
+	}
+
+	if !m.Kpis.IsNull() && !m.Kpis.IsUnknown() {
+		var kpis []costViewKpiModel
+		diags.Append(m.Kpis.ElementsAs(ctx, &kpis, false)...)
+		for _, k := range kpis {
+			view.Properties.Kpis = append(view.Properties.Kpis, subscriptionSettings.KpiProperties{
+				Type:    subscriptionSettings.KpiType(k.Type.ValueString()),
+				ID:      k.ID.ValueString(),
+				Enabled: k.Enabled.ValueBool(),
+			})
+		}
+	}
+
+	if !m.Pivots.IsNull() && !m.Pivots.IsUnknown() {
+		var pivots []costViewColumnModel
+		diags.Append(m.Pivots.ElementsAs(ctx, &pivots, false)...)
+		for _, p := range pivots {
+			view.Properties.Pivots = append(view.Properties.Pivots, subscriptionSettings.PivotProperties{
+				Type: subscriptionSettings.PivotType(p.Type.ValueString()),
+				Name: p.Name.ValueString(),
+			})
+		}
+	}
+
+	return view, diags
+}
+
+// refresh updates the model from view.
+func (m *CostViewResourceModel) refresh(ctx context.Context, view subscriptionSettings.View) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var d diag.Diagnostics
+
+	properties := view.Properties
+
+	if view.ID != "" {
+		m.ID = types.StringValue(view.ID)
+	}
+	m.DisplayName = types.StringValue(properties.DisplayName)
+	m.Chart = types.StringValue(string(properties.Chart))
+	m.Accumulated = types.BoolValue(properties.Accumulated == subscriptionSettings.AccumulatedTypeTrue)
+	m.Metric = stringValueOrNull(string(properties.Metric))
+	m.Currency = stringValueOrNull(properties.Currency)
+
+	query := properties.Query
+	if query == nil {
+		query = &subscriptionSettings.ReportConfigDefinition{}
+	}
+	m.Timeframe = types.StringValue(string(query.Timeframe))
+
+	var currentTimePeriod costExportTimePeriodModel
+	if !m.TimePeriod.IsNull() && !m.TimePeriod.IsUnknown() {
+		diags.Append(m.TimePeriod.As(ctx, &currentTimePeriod, basetypes.ObjectAsOptions{})...)
+	}
+	m.TimePeriod = types.ObjectNull(costExportTimePeriodAttrTypes)
+	if query.TimePeriod != nil {
+		m.TimePeriod, d = types.ObjectValueFrom(ctx, costExportTimePeriodAttrTypes, costExportTimePeriodModel{
+			From: costTimeValue(currentTimePeriod.From, &query.TimePeriod.From),
+			To:   costTimeValue(currentTimePeriod.To, &query.TimePeriod.To),
+		})
+		diags.Append(d...)
+	}
+
+	dataSet := query.DataSet
+	if dataSet == nil {
+		dataSet = &subscriptionSettings.ReportConfigDataset{}
+	}
+	m.Granularity = stringValueOrNull(string(dataSet.Granularity))
+
+	m.Aggregation = types.MapNull(types.ObjectType{AttrTypes: costViewAggregationAttrTypes})
+	if len(dataSet.Aggregation) > 0 {
+		aggregation := make(map[string]costViewAggregationModel, len(dataSet.Aggregation))
+		for alias, a := range dataSet.Aggregation {
+			aggregation[alias] = costViewAggregationModel{
+				Column:   types.StringValue(a.Name),
+				Function: types.StringValue(string(a.Function)),
+			}
+		}
+		m.Aggregation, d = types.MapValueFrom(ctx, types.ObjectType{AttrTypes: costViewAggregationAttrTypes}, aggregation)
+		diags.Append(d...)
+	}
+
+	m.Grouping = types.ListNull(types.ObjectType{AttrTypes: costViewColumnAttrTypes})
+	if len(dataSet.Grouping) > 0 {
+		grouping := make([]costViewColumnModel, 0, len(dataSet.Grouping))
+		for _, g := range dataSet.Grouping {
+			grouping = append(grouping, costViewColumnModel{Type: types.StringValue(string(g.Type)), Name: types.StringValue(g.Name)})
+		}
+		m.Grouping, d = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: costViewColumnAttrTypes}, grouping)
+		diags.Append(d...)
+	}
+
+	m.Sorting = types.ListNull(types.ObjectType{AttrTypes: costViewSortingAttrTypes})
+	if len(dataSet.Sorting) > 0 {
+		sorting := make([]costViewSortingModel, 0, len(dataSet.Sorting))
+		for _, s := range dataSet.Sorting {
+			direction := s.Direction
+			if direction == "" {
+				direction = subscriptionSettings.ReportConfigSortingTypeAscending
+			}
+			sorting = append(sorting, costViewSortingModel{Name: types.StringValue(s.Name), Direction: types.StringValue(string(direction))})
+		}
+		m.Sorting, d = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: costViewSortingAttrTypes}, sorting)
+		diags.Append(d...)
+	}
+
+	m.Columns = types.ListNull(types.StringType)
+	if dataSet.Configuration != nil && len(dataSet.Configuration.Columns) > 0 {
+		m.Columns, d = types.ListValueFrom(ctx, types.StringType, dataSet.Configuration.Columns)
+		diags.Append(d...)
+	}
+
+	m.Kpis = types.ListNull(types.ObjectType{AttrTypes: costViewKpiAttrTypes})
+	if len(properties.Kpis) > 0 {
+		kpis := make([]costViewKpiModel, 0, len(properties.Kpis))
+		for _, k := range properties.Kpis {
+			kpis = append(kpis, costViewKpiModel{
+				Type:    types.StringValue(string(k.Type)),
+				ID:      stringValueOrNull(k.ID),
+				Enabled: types.BoolValue(k.Enabled),
+			})
+		}
+		m.Kpis, d = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: costViewKpiAttrTypes}, kpis)
+		diags.Append(d...)
+	}
+
+	m.Pivots = types.ListNull(types.ObjectType{AttrTypes: costViewColumnAttrTypes})
+	if len(properties.Pivots) > 0 {
+		pivots := make([]costViewColumnModel, 0, len(properties.Pivots))
+		for _, p := range properties.Pivots {
+			pivots = append(pivots, costViewColumnModel{Type: types.StringValue(string(p.Type)), Name: types.StringValue(p.Name)})
+		}
+		m.Pivots, d = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: costViewColumnAttrTypes}, pivots)
+		diags.Append(d...)
+	}
+
+	return diags
+}
@@ -0,0 +1,185 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccCostViewResource(t *testing.T) {
+	skipWithoutTerraform(t)
+	fake := newFakeARM(t)
+	id := fakeSubscriptionScope + "/providers/Microsoft.CostManagement/views/by-service"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fake.providerFactories(),
+		CheckDestroy: func(*terraform.State) error {
+			if fake.getCostResourceAt(id) != nil {
+				return fmt.Errorf("expected view %s to be deleted", id)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + testAccCostViewResourceConfig(fakeSubscriptionScope, "Area"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("azurex_cost_view.test", "id", id),
+					resource.TestCheckResourceAttr("azurex_cost_view.test", "chart", "Area"),
+					resource.TestCheckResourceAttr("azurex_cost_view.test", "accumulated", "true"),
+					resource.TestCheckResourceAttr("azurex_cost_view.test", "aggregation.totalCost.column", "PreTaxCost"),
+					resource.TestCheckResourceAttr("azurex_cost_view.test", "aggregation.totalCost.function", "Sum"),
+					resource.TestCheckResourceAttr("azurex_cost_view.test", "grouping.0.name", "ServiceName"),
+					resource.TestCheckResourceAttr("azurex_cost_view.test", "sorting.0.direction", "Descending"),
+					resource.TestCheckResourceAttr("azurex_cost_view.test", "kpis.#", "1"),
+					resource.TestCheckResourceAttr("azurex_cost_view.test", "kpis.0.enabled", "true"),
+					resource.TestCheckResourceAttr("azurex_cost_view.test", "pivots.#", "2"),
+				),
+			},
+			{
+				Config: fake.providerConfig() + testAccCostViewResourceConfig(fakeSubscriptionScope, "StackedColumn"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("azurex_cost_view.test", "chart", "StackedColumn"),
+				),
+			},
+			{
+				ResourceName:      "azurex_cost_view.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccCostViewResource_managementGroup(t *testing.T) {
+	skipWithoutTerraform(t)
+	fake := newFakeARM(t)
+	scope := "/providers/Microsoft.Management/managementGroups/finops"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fake.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+resource "azurex_cost_view" "test" {
+  name         = "last-quarter"
+  scope        = "/providers/Microsoft.Management/managementGroups/finops"
+  display_name = "Last quarter"
+  timeframe    = "Custom"
+}
+`,
+				ExpectError: regexp.MustCompile(`time_period attribute must be set`),
+			},
+			{
+				Config: fake.providerConfig() + `
+resource "azurex_cost_view" "test" {
+  name         = "last-quarter"
+  scope        = "/providers/Microsoft.Management/managementGroups/finops"
+  display_name = "Last quarter"
+  timeframe    = "Custom"
+  granularity  = "Monthly"
+
+  time_period = {
+    from = "2030-01-01T00:00:00Z"
+    to   = "2030-03-31T00:00:00Z"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("azurex_cost_view.test", "id", scope+"/providers/Microsoft.CostManagement/views/last-quarter"),
+					resource.TestCheckResourceAttr("azurex_cost_view.test", "chart", "Table"),
+					resource.TestCheckResourceAttr("azurex_cost_view.test", "accumulated", "false"),
+					resource.TestCheckResourceAttr("azurex_cost_view.test", "time_period.to", "2030-03-31T00:00:00Z"),
+					resource.TestCheckNoResourceAttr("azurex_cost_view.test", "kpis"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCostViewResource_shared(t *testing.T) {
+	skipWithoutTerraform(t)
+	fake := newFakeARM(t)
+	id := "/providers/Microsoft.CostManagement/views/tenant-costs"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fake.providerFactories(),
+		CheckDestroy: func(*terraform.State) error {
+			if fake.getCostResourceAt(id) != nil {
+				return fmt.Errorf("expected view %s to be deleted", id)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+resource "azurex_cost_view" "test" {
+  name         = "tenant-costs"
+  display_name = "Tenant costs"
+  chart        = "Line"
+  timeframe    = "MonthToDate"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("azurex_cost_view.test", "id", id),
+					resource.TestCheckNoResourceAttr("azurex_cost_view.test", "scope"),
+					resource.TestCheckResourceAttr("azurex_cost_view.test", "chart", "Line"),
+					func(*terraform.State) error {
+						if fake.getCostResourceAt(id) == nil {
+							return fmt.Errorf("expected view %s to be saved without a scope", id)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "azurex_cost_view.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCostViewResourceConfig(scope string, chart string) string {
+	return fmt.Sprintf(`
+resource "azurex_cost_view" "test" {
+  name         = "by-service"
+  scope        = %q
+  display_name = "Costs by service"
+  chart        = %q
+  accumulated  = true
+  metric       = "ActualCost"
+  timeframe    = "MonthToDate"
+  granularity  = "Daily"
+
+  aggregation = {
+    totalCost = {
+      column = "PreTaxCost"
+    }
+  }
+
+  grouping = [
+    { type = "Dimension", name = "ServiceName" },
+  ]
+
+  sorting = [
+    { name = "totalCost", direction = "Descending" },
+  ]
+
+  kpis = [
+    { type = "Forecast" },
+  ]
+
+  pivots = [
+    { type = "Dimension", name = "ResourceGroupName" },
+    { type = "TagKey", name = "team" },
+  ]
+}
+`, scope, chart)
+}
@@ -0,0 +1,188 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/oauth2"
+)
+
+// managedIdentityProbeTimeout bounds how long the default chain waits on the
+// Instance Metadata Service, which never answers off Azure.
+const managedIdentityProbeTimeout = 5 * time.Second
+
+// chainedCredential is one link of the default credential chain.
+type chainedCredential struct {
+	name    string
+	timeout time.Duration
+	build   func() (azcore.TokenCredential, error)
+}
+
+// defaultCredentialChain returns the credentials tried, in order, when no
+// authentication method has been configured explicitly.
+func (c providerConfig) defaultCredentialChain(clientOptions azcore.ClientOptions) []chainedCredential {
+	return []chainedCredential{
+		{
+			name: "environment",
+			build: func() (azcore.TokenCredential, error) {
+				return azidentity.NewEnvironmentCredential(&azidentity.EnvironmentCredentialOptions{ClientOptions: clientOptions})
+			},
+		},
+		{
+			name: "workload identity",
+			build: func() (azcore.TokenCredential, error) {
+				return azidentity.NewWorkloadIdentityCredential(&azidentity.WorkloadIdentityCredentialOptions{
+					ClientOptions: clientOptions,
+					TenantID:      c.TenantID,
+					ClientID:      c.ClientID,
+				})
+			},
+		},
+		{
+			name:    "managed identity",
+			timeout: managedIdentityProbeTimeout,
+			build: func() (azcore.TokenCredential, error) {
+				options := &azidentity.ManagedIdentityCredentialOptions{ClientOptions: clientOptions}
+				if c.ClientID != "" {
+					options.ID = azidentity.ClientID(c.ClientID)
+				}
+				return azidentity.NewManagedIdentityCredential(options)
+			},
+		},
+		{
+			name: "azure cli",
+			build: func() (azcore.TokenCredential, error) {
+				return azidentity.NewAzureCLICredential(&azidentity.AzureCLICredentialOptions{
+					TenantID: c.TenantID,
+				})
+			},
+		},
+	}
+}
+
+// lazyCredentialChain resolves the default credential chain on the first
+// token request rather than when the provider is configured, so runs that
+// never call Azure don't wait on the managed identity probe. The credential
+// that worked is kept for every later request.
+type lazyCredentialChain struct {
+	chain []chainedCredential
+
+	mu    sync.Mutex
+	creds azcore.TokenCredential
+}
+
+var _ azcore.TokenCredential = &lazyCredentialChain{}
+
+func (l *lazyCredentialChain) GetToken(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.creds != nil {
+		return l.creds.GetToken(ctx, options)
+	}
+
+	creds, token, err := resolveCredentialChain(ctx, l.chain, options)
+	if err != nil {
+		return azcore.AccessToken{}, err
+	}
+	l.creds = creds
+	return token, nil
+}
+
+// resolveCredentialChain returns the first credential of the chain able to
+// obtain a token for options, along with that token. The error lists why
+// every link was skipped.
+func resolveCredentialChain(ctx context.Context, chain []chainedCredential, options policy.TokenRequestOptions) (azcore.TokenCredential, azcore.AccessToken, error) {
+	var failures []string
+
+	for _, link := range chain {
+		creds, token, err := link.probe(ctx, options)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", link.name, err))
+			continue
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("authenticated using the default credential chain: %s", link.name))
+		return creds, token, nil
+	}
+
+	return nil, azcore.AccessToken{}, fmt.Errorf("no authentication method was configured and no credential in the default chain could authenticate. "+
+		"Configure a client secret, client certificate, OIDC token or managed identity, or sign in with `az login`:\n  - %s", strings.Join(failures, "\n  - "))
+}
+
+func (link chainedCredential) probe(ctx context.Context, options policy.TokenRequestOptions) (azcore.TokenCredential, azcore.AccessToken, error) {
+	creds, err := link.build()
+	if err != nil {
+		return nil, azcore.AccessToken{}, err
+	}
+
+	if link.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, link.timeout)
+		defer cancel()
+	}
+
+	token, err := creds.GetToken(ctx, options)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, azcore.AccessToken{}, fmt.Errorf("no response within %s", link.timeout)
+		}
+		return nil, azcore.AccessToken{}, err
+	}
+
+	return creds, token, nil
+}
+
+// newAuthorizer returns the go-azure-sdk authorizer for api. Credentials only
+// reachable through azidentity are adapted rather than re-resolved so both
+// SDKs always authenticate as the same identity.
+func newAuthorizer(ctx context.Context, method authMethod, credentials auth.Credentials, creds azcore.TokenCredential, api environments.Api) (auth.Authorizer, error) {
+	if method != authMethodDefault {
+		return auth.NewAuthorizerFromCredentials(ctx, credentials, api)
+	}
+
+	scope, err := environments.Scope(api)
+	if err != nil {
+		return nil, err
+	}
+	return &tokenCredentialAuthorizer{credential: creds, scopes: []string{*scope}}, nil
+}
+
+// tokenCredentialAuthorizer adapts an azcore.TokenCredential to auth.Authorizer.
+type tokenCredentialAuthorizer struct {
+	credential azcore.TokenCredential
+	scopes     []string
+}
+
+var _ auth.Authorizer = &tokenCredentialAuthorizer{}
+
+func (a *tokenCredentialAuthorizer) Token(ctx context.Context, _ *http.Request) (*oauth2.Token, error) {
+	token, err := a.credential.GetToken(ctx, policy.TokenRequestOptions{Scopes: a.scopes})
+	if err != nil {
+		return nil, err
+	}
+	return &oauth2.Token{
+		AccessToken: token.Token,
+		TokenType:   "Bearer",
+		Expiry:      token.ExpiresOn,
+	}, nil
+}
+
+func (a *tokenCredentialAuthorizer) AuxiliaryTokens(_ context.Context, _ *http.Request) ([]*oauth2.Token, error) {
+	// auxiliary tenants are not supported by the default credential chain
+	return []*oauth2.Token{}, nil
+}
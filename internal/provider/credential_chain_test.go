@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+type staticCredential struct {
+	token string
+	err   error
+}
+
+func (c staticCredential) GetToken(ctx context.Context, _ policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	if c.err != nil {
+		return azcore.AccessToken{}, c.err
+	}
+	return azcore.AccessToken{Token: c.token, ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
+
+func TestResolveCredentialChain(t *testing.T) {
+	chain := []chainedCredential{
+		{
+			name: "unconfigured",
+			build: func() (azcore.TokenCredential, error) {
+				return nil, errors.New("missing environment variables")
+			},
+		},
+		{
+			name: "failing",
+			build: func() (azcore.TokenCredential, error) {
+				return staticCredential{err: errors.New("token request rejected")}, nil
+			},
+		},
+		{
+			name: "working",
+			build: func() (azcore.TokenCredential, error) {
+				return staticCredential{token: "chained-token"}, nil
+			},
+		},
+	}
+
+	options := policy.TokenRequestOptions{Scopes: []string{"https://management.azure.com/.default"}}
+	creds, token, err := resolveCredentialChain(context.Background(), chain, options)
+	if err != nil {
+		t.Fatalf("resolving chain: %s", err)
+	}
+	if token.Token != "chained-token" {
+		t.Fatalf("expected the token of the first working credential, got %q", token.Token)
+	}
+
+	authorizer := &tokenCredentialAuthorizer{credential: creds, scopes: options.Scopes}
+	authorized, err := authorizer.Token(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("requesting token: %s", err)
+	}
+	if authorized.AccessToken != "chained-token" {
+		t.Fatalf("expected the first working credential to be used, got token %q", authorized.AccessToken)
+	}
+
+	_, _, err = resolveCredentialChain(context.Background(), chain[:2], options)
+	if err == nil {
+		t.Fatal("expected an error when no credential in the chain authenticates")
+	}
+	for _, want := range []string{"unconfigured: missing environment variables", "failing: token request rejected"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected error to contain %q, got: %s", want, err)
+		}
+	}
+}
+
+func TestLazyCredentialChain(t *testing.T) {
+	builds := 0
+	failing := true
+	chain := []chainedCredential{
+		{
+			name: "flaky",
+			build: func() (azcore.TokenCredential, error) {
+				builds++
+				if failing {
+					return staticCredential{err: errors.New("not signed in")}, nil
+				}
+				return staticCredential{token: "lazy-token"}, nil
+			},
+		},
+	}
+
+	creds := &lazyCredentialChain{chain: chain}
+	if builds != 0 {
+		t.Fatalf("expected the chain to be resolved on the first token request, got %d builds", builds)
+	}
+
+	options := policy.TokenRequestOptions{Scopes: []string{"https://management.azure.com/.default"}}
+	if _, err := creds.GetToken(context.Background(), options); err == nil || !strings.Contains(err.Error(), "flaky: not signed in") {
+		t.Fatalf("expected the chain error, got: %v", err)
+	}
+
+	// A failed resolution is retried, a successful one is kept
+	failing = false
+	for range 2 {
+		token, err := creds.GetToken(context.Background(), options)
+		if err != nil {
+			t.Fatalf("requesting token: %s", err)
+		}
+		if token.Token != "lazy-token" {
+			t.Fatalf("unexpected token %q", token.Token)
+		}
+	}
+	if builds != 2 {
+		t.Fatalf("expected the chain to be resolved twice, got %d builds", builds)
+	}
+}
@@ -0,0 +1,366 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Environment variables consulted, in order, when the matching provider
+// attribute is not set in configuration.
+var (
+	envSubscriptionID            = []string{"ARM_SUBSCRIPTION_ID"}
+	envTenantID                  = []string{"ARM_TENANT_ID", "AZURE_TENANT_ID"}
+	envClientID                  = []string{"ARM_CLIENT_ID", "AZURE_CLIENT_ID"}
+	envClientSecret              = []string{"ARM_CLIENT_SECRET"}
+	envClientCertificate         = []string{"ARM_CLIENT_CERTIFICATE"}
+	envClientCertificatePath     = []string{"ARM_CLIENT_CERTIFICATE_PATH", "ARM_CLIENT_CERTIFICATE_FILE"}
+	envClientCertificatePassword = []string{"ARM_CLIENT_CERTIFICATE_PASSWORD"}
+	envOIDCToken                 = []string{"ARM_OIDC_TOKEN"}
+	envOIDCTokenFilePath         = []string{"ARM_OIDC_TOKEN_FILE_PATH", "AZURE_FEDERATED_TOKEN_FILE"}
+	envUseMSI                    = []string{"ARM_USE_MSI"}
+	envMSIEndpoint               = []string{"ARM_MSI_ENDPOINT"}
+	envUseCLI                    = []string{"ARM_USE_CLI"}
+	envEnvironment               = []string{"ARM_ENVIRONMENT"}
+	envMetadataHost              = []string{"ARM_METADATA_HOSTNAME"}
+	envUseOIDC                   = []string{"ARM_USE_OIDC"}
+	envOIDCRequestURL            = []string{"ARM_OIDC_REQUEST_URL", "ACTIONS_ID_TOKEN_REQUEST_URL", "SYSTEM_OIDCREQUESTURI"}
+	envOIDCRequestToken          = []string{"ARM_OIDC_REQUEST_TOKEN", "ACTIONS_ID_TOKEN_REQUEST_TOKEN", "SYSTEM_ACCESSTOKEN"}
+	envADOServiceConnectionID    = []string{"ARM_ADO_PIPELINE_SERVICE_CONNECTION_ID", "ARM_OIDC_AZURE_SERVICE_CONNECTION_ID"}
+)
+
+// authMethod identifies the mechanism used to authenticate against Azure.
+type authMethod string
+
+const (
+	authMethodDefault           authMethod = "default credential chain"
+	authMethodClientCertificate authMethod = "client certificate"
+	authMethodClientSecret      authMethod = "client secret"
+	authMethodOIDC              authMethod = "oidc token"
+	authMethodGitHubOIDC        authMethod = "github actions oidc token"
+	authMethodADOPipelineOIDC   authMethod = "azure devops pipeline oidc token"
+	authMethodManagedIdentity   authMethod = "managed identity"
+	authMethodAzureCLI          authMethod = "azure cli"
+)
+
+// providerConfig is the provider configuration after every attribute has
+// been resolved. Values set in the provider block always take precedence
+// over environment variables.
+type providerConfig struct {
+	SubscriptionID            string
+	TenantID                  string
+	ClientID                  string
+	ClientSecret              string
+	ClientCertificate         string
+	ClientCertificatePath     string
+	ClientCertificatePassword string
+	OIDCToken                 string
+	OIDCTokenFilePath         string
+	UseMSI                    bool
+	MSIEndpoint               string
+	UseCLI                    bool
+	Environment               string
+	MetadataHost              string
+
+	UseOIDC                        bool
+	OIDCRequestURL                 string
+	OIDCRequestToken               string
+	ADOPipelineServiceConnectionID string
+}
+
+func newProviderConfig(data AzurexProviderModel) providerConfig {
+	return providerConfig{
+		SubscriptionID:            stringValueOrEnv(data.SubscriptionID, envSubscriptionID...),
+		TenantID:                  stringValueOrEnv(data.TenantID, envTenantID...),
+		ClientID:                  stringValueOrEnv(data.ClientID, envClientID...),
+		ClientSecret:              stringValueOrEnv(data.ClientSecret, envClientSecret...),
+		ClientCertificate:         stringValueOrEnv(data.ClientCertificate, envClientCertificate...),
+		ClientCertificatePath:     stringValueOrEnv(data.ClientCertificatePath, envClientCertificatePath...),
+		ClientCertificatePassword: stringValueOrEnv(data.ClientCertificatePassword, envClientCertificatePassword...),
+		OIDCToken:                 stringValueOrEnv(data.OIDCToken, envOIDCToken...),
+		OIDCTokenFilePath:         stringValueOrEnv(data.OIDCTokenFilePath, envOIDCTokenFilePath...),
+		UseMSI:                    boolValueOrEnv(data.UseMSI, envUseMSI...),
+		MSIEndpoint:               stringValueOrEnv(data.MSIEndpoint, envMSIEndpoint...),
+		UseCLI:                    boolValueOrEnv(data.UseCLI, envUseCLI...),
+		Environment:               stringValueOrEnv(data.Environment, envEnvironment...),
+		MetadataHost:              stringValueOrEnv(data.MetadataHost, envMetadataHost...),
+
+		UseOIDC:                        boolValueOrEnv(data.UseOIDC, envUseOIDC...),
+		OIDCRequestURL:                 stringValueOrEnv(data.OIDCRequestURL, envOIDCRequestURL...),
+		OIDCRequestToken:               stringValueOrEnv(data.OIDCRequestToken, envOIDCRequestToken...),
+		ADOPipelineServiceConnectionID: stringValueOrEnv(data.ADOPipelineServiceConnectionID, envADOServiceConnectionID...),
+	}
+}
+
+// stringValueOrEnv returns the configured value when set, otherwise the
+// first non-empty environment variable from keys.
+func stringValueOrEnv(v types.String, keys ...string) string {
+	if !v.IsNull() && !v.IsUnknown() && v.ValueString() != "" {
+		return v.ValueString()
+	}
+	for _, key := range keys {
+		if value := os.Getenv(key); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// boolValueOrEnv returns the configured value when set, otherwise the first
+// environment variable from keys that parses as a boolean.
+func boolValueOrEnv(v types.Bool, keys ...string) bool {
+	if !v.IsNull() && !v.IsUnknown() {
+		return v.ValueBool()
+	}
+	for _, key := range keys {
+		if value, err := strconv.ParseBool(os.Getenv(key)); err == nil {
+			return value
+		}
+	}
+	return false
+}
+
+// authMethod picks the authentication mechanism from the resolved
+// configuration. Certificates win over secrets, which win over OIDC tokens
+// (static, then requested from Azure DevOps or GitHub Actions), which win over
+// managed identity and then the Azure CLI, matching the order used by
+// auth.NewAuthorizerFromCredentials. When nothing is configured the
+// default credential chain is used.
+func (c providerConfig) authMethod() authMethod {
+	switch {
+	case c.ClientCertificate != "" || c.ClientCertificatePath != "":
+		return authMethodClientCertificate
+	case c.ClientSecret != "":
+		return authMethodClientSecret
+	case c.OIDCToken != "" || c.OIDCTokenFilePath != "":
+		return authMethodOIDC
+	case c.UseOIDC && c.ADOPipelineServiceConnectionID != "":
+		return authMethodADOPipelineOIDC
+	case c.UseOIDC:
+		return authMethodGitHubOIDC
+	case c.UseMSI:
+		return authMethodManagedIdentity
+	case c.UseCLI:
+		return authMethodAzureCLI
+	}
+	return authMethodDefault
+}
+
+// validate reports every missing piece of configuration at once so users
+// don't have to fix them one plan at a time.
+func (c providerConfig) validate() diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if c.Environment != "" && c.MetadataHost == "" && !slices.ContainsFunc(supportedEnvironments, func(name string) bool {
+		return strings.EqualFold(name, c.Environment)
+	}) {
+		diags.AddAttributeError(path.Root("environment"), "Invalid provider configuration",
+			fmt.Sprintf("The \"environment\" attribute must be one of %s, got %q.", strings.Join(supportedEnvironments, ", "), c.Environment))
+	}
+
+	method := c.authMethod()
+	switch method {
+	case authMethodDefault, authMethodAzureCLI:
+		// The tenant is optional and the client ID is unused.
+		return diags
+	case authMethodManagedIdentity:
+		if c.MSIEndpoint != "" {
+			if u, err := url.Parse(c.MSIEndpoint); err != nil || !u.IsAbs() {
+				diags.AddAttributeError(path.Root("msi_endpoint"), "Invalid provider configuration",
+					fmt.Sprintf("The \"msi_endpoint\" attribute must be an absolute URL, got %q.", c.MSIEndpoint))
+			}
+		}
+		// The client ID is optional and only selects a user-assigned identity.
+		return diags
+	case authMethodClientCertificate:
+		if c.ClientCertificate != "" && c.ClientCertificatePath != "" {
+			diags.AddAttributeError(path.Root("client_certificate"), "Invalid provider configuration",
+				"Only one of \"client_certificate\" and \"client_certificate_path\" can be set.")
+		} else if _, err := c.loadClientCertificate(); err != nil {
+			diags.Append(err.diagnostic())
+		}
+	case authMethodGitHubOIDC, authMethodADOPipelineOIDC:
+		if c.OIDCRequestURL == "" {
+			diags.Append(missingAttributeDiagnostic("oidc_request_url", envOIDCRequestURL, method))
+		} else if u, err := url.Parse(c.OIDCRequestURL); err != nil || !u.IsAbs() {
+			diags.AddAttributeError(path.Root("oidc_request_url"), "Invalid provider configuration",
+				fmt.Sprintf("The \"oidc_request_url\" attribute must be an absolute URL, got %q.", c.OIDCRequestURL))
+		}
+		if c.OIDCRequestToken == "" {
+			diags.Append(missingAttributeDiagnostic("oidc_request_token", envOIDCRequestToken, method))
+		}
+	}
+
+	if c.TenantID == "" {
+		diags.Append(missingAttributeDiagnostic("tenant_id", envTenantID, method))
+	}
+	if c.ClientID == "" {
+		diags.Append(missingAttributeDiagnostic("client_id", envClientID, method))
+	}
+
+	return diags
+}
+
+func missingAttributeDiagnostic(attribute string, envs []string, method ...authMethod) diag.Diagnostic {
+	detail := fmt.Sprintf("The %q attribute must be set in the provider configuration or through the %s environment variable",
+		attribute, strings.Join(envs, " or "))
+	if len(method) > 0 {
+		detail += fmt.Sprintf(" when authenticating using a %s", method[0])
+	}
+	return diag.NewAttributeErrorDiagnostic(path.Root(attribute), "Missing provider configuration", detail+".")
+}
+
+// credentials builds the go-azure-sdk credentials used by the autorest
+// authorizers and the azidentity credential used by the ARM clients. Only the
+// selected authentication method is enabled so both always agree.
+func (c providerConfig) credentials(ctx context.Context, env environments.Environment) (auth.Credentials, azcore.TokenCredential, error) {
+	credentials := auth.Credentials{
+		Environment: env,
+		TenantID:    c.TenantID,
+		ClientID:    c.ClientID,
+	}
+
+	configuration, err := cloudConfiguration(env)
+	if err != nil {
+		return credentials, nil, err
+	}
+	clientOptions := azcore.ClientOptions{Cloud: configuration}
+
+	switch c.authMethod() {
+	case authMethodClientCertificate:
+		credentials.EnableAuthenticatingUsingClientCertificate = true
+		credentials.ClientCertificatePassword = c.ClientCertificatePassword
+
+		certificate, certErr := c.loadClientCertificate()
+		if certErr != nil {
+			return credentials, nil, certErr
+		}
+		if c.ClientCertificate != "" {
+			credentials.ClientCertificateData = certificate.data
+		} else {
+			credentials.ClientCertificatePath = c.ClientCertificatePath
+		}
+
+		creds, err := azidentity.NewClientCertificateCredential(c.TenantID, c.ClientID, certificate.certs, certificate.key, &azidentity.ClientCertificateCredentialOptions{ClientOptions: clientOptions})
+		return credentials, creds, err
+
+	case authMethodClientSecret:
+		credentials.EnableAuthenticatingUsingClientSecret = true
+		credentials.ClientSecret = c.ClientSecret
+
+		creds, err := azidentity.NewClientSecretCredential(c.TenantID, c.ClientID, c.ClientSecret, &azidentity.ClientSecretCredentialOptions{ClientOptions: clientOptions})
+		return credentials, creds, err
+
+	case authMethodOIDC:
+		credentials.EnableAuthenticationUsingOIDC = true
+
+		if c.OIDCToken != "" {
+			credentials.OIDCAssertionToken = c.OIDCToken
+
+			creds, err := azidentity.NewClientAssertionCredential(c.TenantID, c.ClientID, func(context.Context) (string, error) {
+				return c.OIDCToken, nil
+			}, &azidentity.ClientAssertionCredentialOptions{ClientOptions: clientOptions})
+			return credentials, creds, err
+		}
+
+		token, err := os.ReadFile(c.OIDCTokenFilePath)
+		if err != nil {
+			return credentials, nil, fmt.Errorf("reading oidc token file %q: %w", c.OIDCTokenFilePath, err)
+		}
+		credentials.OIDCAssertionToken = strings.TrimSpace(string(token))
+
+		creds, err := azidentity.NewWorkloadIdentityCredential(&azidentity.WorkloadIdentityCredentialOptions{
+			ClientOptions: clientOptions,
+			TenantID:      c.TenantID,
+			ClientID:      c.ClientID,
+			TokenFilePath: c.OIDCTokenFilePath,
+		})
+		return credentials, creds, err
+
+	case authMethodGitHubOIDC, authMethodADOPipelineOIDC:
+		credentials.OIDCTokenRequestURL = c.OIDCRequestURL
+		credentials.OIDCTokenRequestToken = c.OIDCRequestToken
+		if c.ADOPipelineServiceConnectionID != "" {
+			credentials.EnableAuthenticationUsingADOPipelineOIDC = true
+			credentials.ADOPipelineServiceConnectionID = c.ADOPipelineServiceConnectionID
+		} else {
+			credentials.EnableAuthenticationUsingGitHubOIDC = true
+		}
+
+		requester := &oidcTokenRequester{
+			requestURL:          c.OIDCRequestURL,
+			requestToken:        c.OIDCRequestToken,
+			serviceConnectionID: c.ADOPipelineServiceConnectionID,
+		}
+		creds, err := azidentity.NewClientAssertionCredential(c.TenantID, c.ClientID, requester.token, &azidentity.ClientAssertionCredentialOptions{ClientOptions: clientOptions})
+		return credentials, creds, err
+
+	case authMethodManagedIdentity:
+		credentials.EnableAuthenticatingUsingManagedIdentity = true
+		credentials.CustomManagedIdentityEndpoint = c.MSIEndpoint
+
+		options := &azidentity.ManagedIdentityCredentialOptions{ClientOptions: clientOptions}
+		if c.ClientID != "" {
+			options.ID = azidentity.ClientID(c.ClientID)
+		}
+		if c.MSIEndpoint != "" {
+			endpoint, err := url.Parse(c.MSIEndpoint)
+			if err != nil {
+				return credentials, nil, fmt.Errorf("parsing msi endpoint %q: %w", c.MSIEndpoint, err)
+			}
+			options.Transport = &msiEndpointTransport{endpoint: endpoint}
+		}
+
+		creds, err := azidentity.NewManagedIdentityCredential(options)
+		return credentials, creds, err
+
+	case authMethodAzureCLI:
+		credentials.EnableAuthenticatingUsingAzureCLI = true
+		credentials.AzureCliSubscriptionIDHint = c.SubscriptionID
+
+		creds, err := azidentity.NewAzureCLICredential(&azidentity.AzureCLICredentialOptions{
+			TenantID: c.TenantID,
+		})
+		return credentials, creds, err
+	}
+
+	return credentials, &lazyCredentialChain{chain: c.defaultCredentialChain(clientOptions)}, nil
+}
+
+// msiEndpointTransport sends managed identity token requests to a custom
+// endpoint, since azidentity only talks to the well-known IMDS address.
+type msiEndpointTransport struct {
+	endpoint *url.URL
+	client   *http.Client
+}
+
+func (t *msiEndpointTransport) Do(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = t.endpoint.Scheme
+	req.URL.Host = t.endpoint.Host
+	if t.endpoint.Path != "" {
+		req.URL.Path = t.endpoint.Path
+	}
+	req.Host = t.endpoint.Host
+
+	client := t.client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return client.Do(req)
+}
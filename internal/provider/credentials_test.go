@@ -0,0 +1,203 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// newTestIMDSServer stands in for the Azure Instance Metadata Service and
+// records the client_id each token request asked for.
+func newTestIMDSServer(t *testing.T, clientIDs *[]string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata") != "true" {
+			http.Error(w, "missing Metadata header", http.StatusBadRequest)
+			return
+		}
+		if r.URL.Path != "/metadata/identity/oauth2/token" {
+			http.NotFound(w, r)
+			return
+		}
+		*clientIDs = append(*clientIDs, r.URL.Query().Get("client_id"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "imds-token",
+			"expires_in":   "3600",
+			"expires_on":   strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10),
+			"resource":     r.URL.Query().Get("resource"),
+			"token_type":   "Bearer",
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestProviderConfig_managedIdentity(t *testing.T) {
+	for _, clientID := range []string{"", "00000000-0000-0000-0000-000000000001"} {
+		t.Run(fmt.Sprintf("client_id=%q", clientID), func(t *testing.T) {
+			var requested []string
+			server := newTestIMDSServer(t, &requested)
+
+			config := newProviderConfig(AzurexProviderModel{
+				SubscriptionID: types.StringValue("00000000-0000-0000-0000-000000000000"),
+				ClientID:       types.StringValue(clientID),
+				UseMSI:         types.BoolValue(true),
+				MSIEndpoint:    types.StringValue(server.URL + "/metadata/identity/oauth2/token"),
+			})
+
+			if method := config.authMethod(); method != authMethodManagedIdentity {
+				t.Fatalf("expected managed identity authentication, got %q", method)
+			}
+			if diags := config.validate(); diags.HasError() {
+				t.Fatalf("unexpected validation errors: %v", diags)
+			}
+
+			env := environments.AzurePublic()
+			credentials, creds, err := config.credentials(context.Background(), *env)
+			if err != nil {
+				t.Fatalf("building credentials: %s", err)
+			}
+
+			token, err := creds.GetToken(context.Background(), policy.TokenRequestOptions{
+				Scopes: []string{"https://management.azure.com/.default"},
+			})
+			if err != nil {
+				t.Fatalf("requesting identity token: %s", err)
+			}
+			if token.Token != "imds-token" {
+				t.Fatalf("expected identity token %q, got %q", "imds-token", token.Token)
+			}
+
+			authorizer, err := auth.NewAuthorizerFromCredentials(context.Background(), credentials, env.ResourceManager)
+			if err != nil {
+				t.Fatalf("building authorizer: %s", err)
+			}
+			req, _ := http.NewRequest(http.MethodGet, "https://management.azure.com", nil)
+			oauthToken, err := authorizer.Token(context.Background(), req)
+			if err != nil {
+				t.Fatalf("requesting authorizer token: %s", err)
+			}
+			if oauthToken.AccessToken != "imds-token" {
+				t.Fatalf("expected authorizer token %q, got %q", "imds-token", oauthToken.AccessToken)
+			}
+
+			if len(requested) != 2 {
+				t.Fatalf("expected 2 token requests against the IMDS stand-in, got %d", len(requested))
+			}
+			for _, got := range requested {
+				if got != clientID {
+					t.Fatalf("expected token requests for client_id %q, got %q", clientID, got)
+				}
+			}
+		})
+	}
+}
+
+func TestProviderConfig_invalidMSIEndpoint(t *testing.T) {
+	config := newProviderConfig(AzurexProviderModel{
+		SubscriptionID: types.StringValue("00000000-0000-0000-0000-000000000000"),
+		UseMSI:         types.BoolValue(true),
+		MSIEndpoint:    types.StringValue("not-a-url"),
+	})
+
+	if diags := config.validate(); !diags.HasError() {
+		t.Fatal("expected a validation error for a relative msi_endpoint")
+	}
+}
+
+func TestNewProviderConfig_precedence(t *testing.T) {
+	cases := map[string]struct {
+		data       AzurexProviderModel
+		env        map[string]string
+		tenantID   string
+		useMSI     bool
+		missingEnv bool
+	}{
+		"config only": {
+			data:     AzurexProviderModel{TenantID: types.StringValue("config-tenant"), UseMSI: types.BoolValue(true)},
+			tenantID: "config-tenant",
+			useMSI:   true,
+		},
+		"env only": {
+			env:      map[string]string{"ARM_TENANT_ID": "env-tenant", "ARM_USE_MSI": "true"},
+			tenantID: "env-tenant",
+			useMSI:   true,
+		},
+		"fallback env": {
+			env:      map[string]string{"AZURE_TENANT_ID": "azure-tenant"},
+			tenantID: "azure-tenant",
+		},
+		"config and env": {
+			data:     AzurexProviderModel{TenantID: types.StringValue("config-tenant"), UseMSI: types.BoolValue(false)},
+			env:      map[string]string{"ARM_TENANT_ID": "env-tenant", "AZURE_TENANT_ID": "azure-tenant", "ARM_USE_MSI": "true"},
+			tenantID: "config-tenant",
+		},
+		"empty config falls back to env": {
+			data:     AzurexProviderModel{TenantID: types.StringValue(""), UseMSI: types.BoolNull()},
+			env:      map[string]string{"ARM_TENANT_ID": "env-tenant", "ARM_USE_MSI": "true"},
+			tenantID: "env-tenant",
+			useMSI:   true,
+		},
+		"neither": {
+			missingEnv: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			for _, key := range append(append([]string{}, envTenantID...), envUseMSI...) {
+				t.Setenv(key, tc.env[key])
+			}
+
+			config := newProviderConfig(tc.data)
+			if config.TenantID != tc.tenantID {
+				t.Fatalf("expected tenant ID %q, got %q", tc.tenantID, config.TenantID)
+			}
+			if config.UseMSI != tc.useMSI {
+				t.Fatalf("expected use_msi %t, got %t", tc.useMSI, config.UseMSI)
+			}
+
+			// A client secret makes the tenant and client IDs required
+			config.ClientID = "00000000-0000-0000-0000-000000000001"
+			config.ClientSecret = "secret"
+			diags := config.validate()
+			if !tc.missingEnv {
+				if diags.HasError() {
+					t.Fatalf("unexpected validation errors: %v", diags)
+				}
+				return
+			}
+
+			if diags.ErrorsCount() != 1 {
+				t.Fatalf("expected one validation error, got %v", diags)
+			}
+			got, ok := diags[0].(diag.DiagnosticWithPath)
+			if !ok || !got.Path().Equal(path.Root("tenant_id")) || got.Summary() != "Missing provider configuration" {
+				t.Fatalf("expected a missing tenant_id diagnostic, got %v", diags[0])
+			}
+			if want := "ARM_TENANT_ID or AZURE_TENANT_ID environment variable"; !strings.Contains(got.Detail(), want) {
+				t.Fatalf("expected the detail to mention %q, got %q", want, got.Detail())
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	subscriptionSettings "github.com/ekristen/terraform-provider-azurex/internal/azure/subscriptions"
+)
+
+// responseErrorDiagnostic turns err into an error diagnostic, spelling out
+// the ARM error code, message, target and details when Azure returned them.
+func responseErrorDiagnostic(summary string, err error) diag.Diagnostic {
+	var respErr *subscriptionSettings.ResponseError
+	if !errors.As(err, &respErr) || respErr.Detail.Message == "" {
+		return diag.NewErrorDiagnostic(summary, err.Error())
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Azure responded with status %d", respErr.StatusCode)
+	if respErr.Detail.Code != "" {
+		fmt.Fprintf(&sb, " (%s)", respErr.Detail.Code)
+	}
+	fmt.Fprintf(&sb, ": %s", respErr.Detail.Message)
+	if respErr.Detail.Target != "" {
+		fmt.Fprintf(&sb, "\n\nTarget: %s", respErr.Detail.Target)
+	}
+	if len(respErr.Detail.Details) > 0 {
+		sb.WriteString("\n\nDetails:")
+		for _, detail := range respErr.Detail.Details {
+			fmt.Fprintf(&sb, "\n  - %s: %s", detail.Code, detail.Message)
+			if detail.Target != "" {
+				fmt.Fprintf(&sb, " (target: %s)", detail.Target)
+			}
+		}
+	}
+	if req := respErr.RawResponse.Request; req != nil {
+		fmt.Fprintf(&sb, "\n\nRequest: %s %s", req.Method, req.URL.Path)
+	}
+
+	return diag.NewErrorDiagnostic(summary, sb.String())
+}
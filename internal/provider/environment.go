@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+)
+
+const defaultEnvironment = "public"
+
+// supportedEnvironments lists the names accepted by the environment attribute.
+var supportedEnvironments = []string{"public", "usgovernment", "china"}
+
+// azureEnvironment loads the Azure environment from the metadata host when
+// one is configured, otherwise from the well-known environment name.
+func (c providerConfig) azureEnvironment(ctx context.Context) (*environments.Environment, error) {
+	if c.MetadataHost != "" {
+		endpoint := c.MetadataHost
+		if !strings.Contains(endpoint, "://") {
+			endpoint = "https://" + endpoint
+		}
+		return environments.FromEndpoint(ctx, endpoint)
+	}
+
+	name := c.Environment
+	if name == "" {
+		name = defaultEnvironment
+	}
+	for _, supported := range supportedEnvironments {
+		if strings.EqualFold(name, supported) {
+			return environments.FromName(supported)
+		}
+	}
+
+	return nil, fmt.Errorf("unsupported environment %q, expected one of: %s", name, strings.Join(supportedEnvironments, ", "))
+}
+
+// cloudConfiguration translates an environment into the configuration used by
+// azcore based credentials and clients, so tokens are requested from the
+// right authority for the right audience.
+func cloudConfiguration(env environments.Environment) (cloud.Configuration, error) {
+	endpoint, ok := env.ResourceManager.Endpoint()
+	if !ok {
+		return cloud.Configuration{}, fmt.Errorf("environment %q has no resource manager endpoint", env.Name)
+	}
+
+	audience, ok := env.ResourceManager.ResourceIdentifier()
+	if !ok {
+		audience = endpoint
+	}
+
+	configuration := cloud.Configuration{
+		Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+			cloud.ResourceManager: {
+				Endpoint: *endpoint,
+				Audience: *audience,
+			},
+		},
+	}
+	if env.Authorization != nil {
+		configuration.ActiveDirectoryAuthorityHost = env.Authorization.LoginEndpoint
+	}
+
+	return configuration, nil
+}
+
+// armClientOptions returns the options every ARM client must be created with.
+func armClientOptions(configuration cloud.Configuration) *arm.ClientOptions {
+	return &arm.ClientOptions{
+		ClientOptions: azcore.ClientOptions{
+			Cloud: configuration,
+		},
+	}
+}
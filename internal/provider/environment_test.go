@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+)
+
+func TestProviderConfig_azureEnvironment(t *testing.T) {
+	cases := map[string]struct {
+		endpoint  string
+		authority string
+	}{
+		"":             {"https://management.azure.com", "https://login.microsoftonline.com"},
+		"public":       {"https://management.azure.com", "https://login.microsoftonline.com"},
+		"USGovernment": {"https://management.usgovcloudapi.net", "https://login.microsoftonline.us"},
+		"china":        {"https://management.chinacloudapi.cn", "https://login.chinacloudapi.cn"},
+	}
+
+	for name, want := range cases {
+		t.Run(name, func(t *testing.T) {
+			env, err := providerConfig{Environment: name}.azureEnvironment(context.Background())
+			if err != nil {
+				t.Fatalf("loading environment: %s", err)
+			}
+
+			configuration, err := cloudConfiguration(*env)
+			if err != nil {
+				t.Fatalf("building cloud configuration: %s", err)
+			}
+
+			if got := configuration.Services[cloud.ResourceManager].Endpoint; got != want.endpoint {
+				t.Fatalf("expected resource manager endpoint %q, got %q", want.endpoint, got)
+			}
+			if got := configuration.Services[cloud.ResourceManager].Audience; got != want.endpoint {
+				t.Fatalf("expected resource manager audience %q, got %q", want.endpoint, got)
+			}
+			if got := configuration.ActiveDirectoryAuthorityHost; got != want.authority {
+				t.Fatalf("expected authority host %q, got %q", want.authority, got)
+			}
+		})
+	}
+
+	if _, err := (providerConfig{Environment: "germany"}).azureEnvironment(context.Background()); err == nil {
+		t.Fatal("expected an error for an unsupported environment")
+	}
+}
@@ -0,0 +1,645 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// costRoutes returns the fake Cost Management routes. Resources such as
+// exports are kept as the JSON documents they were created with, keyed by
+// their lower-cased ID.
+func (f *fakeARM) costRoutes(scope string) []fakeRoute {
+	const costManagement = `/providers/Microsoft\.CostManagement/`
+	exports := regexp.MustCompile(`(?i)^` + scope + costManagement + `exports/([^/]+)$`)
+	scheduledActions := regexp.MustCompile(`(?i)^` + scope + costManagement + `scheduledActions/([^/]+)$`)
+	// Views saved without a scope are shared with the whole tenant
+	views := regexp.MustCompile(`(?i)^` + scope + `?` + costManagement + `views/([^/]+)$`)
+	alerts := regexp.MustCompile(`(?i)^` + scope + costManagement + `alerts/([^/]+)$`)
+
+	return []fakeRoute{
+		{http.MethodGet, exports, f.getCostResource},
+		{http.MethodPut, exports, f.putCostResource("Microsoft.CostManagement/exports")},
+		{http.MethodDelete, exports, f.deleteCostResource},
+		{http.MethodPost, regexp.MustCompile(`(?i)^` + scope + costManagement + `exports/([^/]+)/run$`), f.runExport},
+		{http.MethodGet, scheduledActions, f.getCostResource},
+		{http.MethodPut, scheduledActions, f.putCostResource("Microsoft.CostManagement/ScheduledActions")},
+		{http.MethodDelete, scheduledActions, f.deleteCostResource},
+		{http.MethodGet, views, f.getCostResource},
+		{http.MethodPut, views, f.putCostResource("Microsoft.CostManagement/Views")},
+		{http.MethodDelete, views, f.deleteCostResource},
+		{http.MethodPost, regexp.MustCompile(`(?i)^` + scope + costManagement + `query$`), f.queryCosts},
+		{http.MethodPost, regexp.MustCompile(`(?i)^` + scope + costManagement + `forecast$`), f.forecastCosts},
+		{http.MethodGet, regexp.MustCompile(`(?i)^` + scope + costManagement + `alerts$`), f.listAlerts},
+		{http.MethodGet, alerts, f.getAlert},
+		{http.MethodPatch, alerts, f.patchAlert},
+		{http.MethodPost, regexp.MustCompile(`(?i)^` + scope + costManagement + `generateCostDetailsReport$`), f.generateCostDetailsReport},
+		{http.MethodGet, regexp.MustCompile(`(?i)^` + scope + costManagement + `costDetailsOperationResults/([^/]+)$`), f.getCostDetailsOperation},
+		{http.MethodGet, regexp.MustCompile(`(?i)^` + scope + costManagement + `benefitRecommendations$`), f.listBenefitRecommendations},
+	}
+}
+
+// costResourceKey returns the key of the resource a request is for, the
+// path without the trailing action such as /run.
+func costResourceKey(r *http.Request, action string) string {
+	path := r.URL.Path
+	if action != "" {
+		path = path[:len(path)-len(action)]
+	}
+	return strings.ToLower(path)
+}
+
+// getCostResourceAt returns the stored resource with the given ID.
+func (f *fakeARM) getCostResourceAt(id string) map[string]any {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.costResources[strings.ToLower(id)]
+}
+
+func (f *fakeARM) getCostResource(w http.ResponseWriter, r *http.Request, match []string) {
+	key := costResourceKey(r, "")
+	resource, ok := f.costResources[key]
+	if !ok {
+		writeARMError(w, http.StatusNotFound, "NotFound", fmt.Sprintf("'%s' was not found.", match[2]))
+		return
+	}
+
+	if strings.EqualFold(r.URL.Query().Get("$expand"), "runHistory") {
+		resource = copyCostResource(resource)
+		properties, _ := resource["properties"].(map[string]any)
+		properties["runHistory"] = map[string]any{"value": f.exportRunsOrEmpty(key)}
+	}
+	writeJSON(w, http.StatusOK, resource)
+}
+
+// putCostResource stores the body as a resource of resourceType, checking
+// the eTag on updates like Cost Management does.
+func (f *fakeARM) putCostResource(resourceType string) func(w http.ResponseWriter, r *http.Request, match []string) {
+	return func(w http.ResponseWriter, r *http.Request, match []string) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeARMError(w, http.StatusBadRequest, "InvalidRequestContent", err.Error())
+			return
+		}
+
+		key := costResourceKey(r, "")
+		existing, existed := f.costResources[key]
+		if existed && body["eTag"] != existing["eTag"] {
+			writeARMError(w, http.StatusPreconditionFailed, "PreconditionFailed", "The eTag does not match the current version of the resource.")
+			return
+		}
+
+		body["id"] = r.URL.Path
+		body["name"] = match[2]
+		body["type"] = resourceType
+		body["eTag"] = fmt.Sprintf(`"%x"`, time.Now().UnixNano())
+		if properties, ok := body["properties"].(map[string]any); ok {
+			delete(properties, "runHistory")
+			if schedule, ok := properties["schedule"].(map[string]any); ok && schedule["status"] == "Active" {
+				if period, ok := schedule["recurrencePeriod"].(map[string]any); ok {
+					properties["nextRunTimeEstimate"] = period["from"]
+				}
+			}
+		}
+		f.costResources[key] = body
+
+		status := http.StatusCreated
+		if existed {
+			status = http.StatusOK
+		}
+		writeJSON(w, status, body)
+	}
+}
+
+func (f *fakeARM) deleteCostResource(w http.ResponseWriter, r *http.Request, match []string) {
+	key := costResourceKey(r, "")
+	if _, ok := f.costResources[key]; !ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	delete(f.costResources, key)
+	delete(f.exportRuns, key)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (f *fakeARM) runExport(w http.ResponseWriter, r *http.Request, match []string) {
+	key := costResourceKey(r, "/run")
+	if _, ok := f.costResources[key]; !ok {
+		writeARMError(w, http.StatusNotFound, "NotFound", fmt.Sprintf("Export '%s' was not found.", match[2]))
+		return
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	f.exportRuns[key] = append(f.exportRuns[key], map[string]any{
+		"id":   fmt.Sprintf("%s/Run/%d", r.URL.Path[:len(key)], len(f.exportRuns[key])+1),
+		"name": fmt.Sprintf("%d", len(f.exportRuns[key])+1),
+		"properties": map[string]any{
+			"executionType":       "OnDemand",
+			"status":              "Completed",
+			"submittedBy":         "fake@example.com",
+			"submittedTime":       now,
+			"processingStartTime": now,
+			"processingEndTime":   now,
+			"fileName":            "exports/" + match[2] + ".csv",
+		},
+	})
+	w.WriteHeader(http.StatusOK)
+}
+
+func (f *fakeARM) exportRunsOrEmpty(key string) []map[string]any {
+	if runs := f.exportRuns[key]; runs != nil {
+		return runs
+	}
+	return []map[string]any{}
+}
+
+// copyCostResource returns a copy of resource that can be changed without
+// touching the stored properties.
+func copyCostResource(resource map[string]any) map[string]any {
+	copied := make(map[string]any, len(resource))
+	for k, v := range resource {
+		copied[k] = v
+	}
+	if properties, ok := resource["properties"].(map[string]any); ok {
+		copiedProperties := make(map[string]any, len(properties))
+		for k, v := range properties {
+			copiedProperties[k] = v
+		}
+		copied["properties"] = copiedProperties
+	}
+	return copied
+}
+
+// fakeUsage is the usage every fake scope reports to cost queries.
+var fakeUsage = []struct {
+	date          int
+	serviceName   string
+	resourceGroup string
+	cost          float64
+}{
+	{20300101, "Storage", "app", 1.5},
+	{20300101, "Virtual Machines", "app", 10},
+	{20300102, "Storage", "data", 2.5},
+	{20300102, "Virtual Machines", "app", 10},
+}
+
+// fakeQueryPageSize is the number of rows per page of query results, small
+// so the client has to follow nextLink.
+const fakeQueryPageSize = 2
+
+// queryCosts aggregates fakeUsage like the Cost Management query API,
+// supporting Daily granularity, grouping by ServiceName and
+// ResourceGroupName and In filters on those dimensions.
+func (f *fakeARM) queryCosts(w http.ResponseWriter, r *http.Request, match []string) {
+	var body struct {
+		Dataset struct {
+			Granularity string `json:"granularity"`
+			Aggregation map[string]struct {
+				Name string `json:"name"`
+			} `json:"aggregation"`
+			Grouping []struct {
+				Name string `json:"name"`
+			} `json:"grouping"`
+			Filter *fakeQueryFilter `json:"filter"`
+		} `json:"dataset"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeARMError(w, http.StatusBadRequest, "InvalidRequestContent", err.Error())
+		return
+	}
+
+	aliases := make([]string, 0, len(body.Dataset.Aggregation))
+	for alias := range body.Dataset.Aggregation {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+
+	columns := make([]map[string]string, 0)
+	for _, alias := range aliases {
+		columns = append(columns, map[string]string{"name": alias, "type": "Number"})
+	}
+	daily := body.Dataset.Granularity == "Daily"
+	if daily {
+		columns = append(columns, map[string]string{"name": "UsageDate", "type": "Number"})
+	}
+	for _, g := range body.Dataset.Grouping {
+		columns = append(columns, map[string]string{"name": g.Name, "type": "String"})
+	}
+	columns = append(columns, map[string]string{"name": "Currency", "type": "String"})
+
+	var keys []string
+	groups := make(map[string][]any)
+	for _, usage := range fakeUsage {
+		dimensions := map[string]string{"ServiceName": usage.serviceName, "ResourceGroupName": usage.resourceGroup}
+		if !body.Dataset.Filter.matches(dimensions) {
+			continue
+		}
+
+		var key []any
+		if daily {
+			key = append(key, float64(usage.date))
+		}
+		for _, g := range body.Dataset.Grouping {
+			key = append(key, dimensions[g.Name])
+		}
+		id := fmt.Sprint(key...)
+		row, ok := groups[id]
+		if !ok {
+			row = make([]any, len(aliases))
+			for i := range row {
+				row[i] = float64(0)
+			}
+			row = append(append(row, key...), "USD")
+			keys = append(keys, id)
+		}
+		for i := range aliases {
+			row[i] = row[i].(float64) + usage.cost
+		}
+		groups[id] = row
+	}
+
+	start, _ := strconv.Atoi(r.URL.Query().Get("$skiptoken"))
+	end := min(start+fakeQueryPageSize, len(keys))
+	rows := make([][]any, 0)
+	for _, id := range keys[min(start, end):end] {
+		rows = append(rows, groups[id])
+	}
+	properties := map[string]any{"columns": columns, "rows": rows}
+	if end < len(keys) {
+		properties["nextLink"] = fmt.Sprintf("%s%s?$skiptoken=%d", f.server.URL, r.URL.Path, end)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"id":         match[1] + "/providers/Microsoft.CostManagement/query/" + strconv.Itoa(start),
+		"name":       strconv.Itoa(start),
+		"type":       "Microsoft.CostManagement/query",
+		"properties": properties,
+	})
+}
+
+// fakeQueryFilter is the subset of query filters queryCosts understands.
+type fakeQueryFilter struct {
+	And        []fakeQueryFilter `json:"and"`
+	Dimensions *struct {
+		Name   string   `json:"name"`
+		Values []string `json:"values"`
+	} `json:"dimensions"`
+}
+
+func (q *fakeQueryFilter) matches(dimensions map[string]string) bool {
+	if q == nil {
+		return true
+	}
+	for _, and := range q.And {
+		if !and.matches(dimensions) {
+			return false
+		}
+	}
+	if q.Dimensions == nil {
+		return true
+	}
+	for _, v := range q.Dimensions.Values {
+		if strings.EqualFold(dimensions[q.Dimensions.Name], v) {
+			return true
+		}
+	}
+	return false
+}
+
+// fakeForecastActualDays is the number of days at the start of a forecast
+// that have already passed and so report actual costs.
+const fakeForecastActualDays = 2
+
+// forecastCosts forecasts 10 per day and aggregation of the time period,
+// after fakeForecastActualDays of 8 per day actual costs that are only
+// reported when includeActualCost is set.
+func (f *fakeARM) forecastCosts(w http.ResponseWriter, r *http.Request, match []string) {
+	var body struct {
+		IncludeActualCost bool `json:"includeActualCost"`
+		TimePeriod        struct {
+			From time.Time `json:"from"`
+			To   time.Time `json:"to"`
+		} `json:"timePeriod"`
+		Dataset struct {
+			Granularity string         `json:"granularity"`
+			Aggregation map[string]any `json:"aggregation"`
+		} `json:"dataset"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeARMError(w, http.StatusBadRequest, "InvalidRequestContent", err.Error())
+		return
+	}
+
+	aliases := make([]string, 0, len(body.Dataset.Aggregation))
+	for alias := range body.Dataset.Aggregation {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+
+	columns := make([]map[string]string, 0)
+	for _, alias := range aliases {
+		columns = append(columns, map[string]string{"name": alias, "type": "Number"})
+	}
+	daily := body.Dataset.Granularity == "Daily"
+	if daily {
+		columns = append(columns, map[string]string{"name": "UsageDate", "type": "Number"})
+	}
+	columns = append(columns,
+		map[string]string{"name": "CostStatus", "type": "String"},
+		map[string]string{"name": "Currency", "type": "String"},
+	)
+
+	var keys []string
+	groups := make(map[string][]any)
+	for day := 0; !body.TimePeriod.From.AddDate(0, 0, day).After(body.TimePeriod.To); day++ {
+		status, cost := "Forecast", float64(10)
+		if day < fakeForecastActualDays {
+			if !body.IncludeActualCost {
+				continue
+			}
+			status, cost = "Actual", 8
+		}
+
+		var key []any
+		if daily {
+			date, _ := strconv.Atoi(body.TimePeriod.From.AddDate(0, 0, day).Format("20060102"))
+			key = append(key, float64(date))
+		}
+		key = append(key, status)
+		id := fmt.Sprint(key...)
+		row, ok := groups[id]
+		if !ok {
+			row = make([]any, len(aliases))
+			for i := range row {
+				row[i] = float64(0)
+			}
+			row = append(append(row, key...), "USD")
+			keys = append(keys, id)
+		}
+		for i := range aliases {
+			row[i] = row[i].(float64) + cost
+		}
+		groups[id] = row
+	}
+
+	rows := make([][]any, 0, len(keys))
+	for _, id := range keys {
+		rows = append(rows, groups[id])
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"id":         match[1] + "/providers/Microsoft.CostManagement/forecast/0",
+		"name":       "0",
+		"type":       "Microsoft.CostManagement/forecast",
+		"properties": map[string]any{"columns": columns, "rows": rows},
+	})
+}
+
+// fakeAlerts are the alerts every fake scope has, keyed by name. Alerts
+// whose status was changed are kept in costResources.
+var fakeAlerts = []map[string]any{
+	{
+		"name": "budget-80",
+		"properties": map[string]any{
+			"definition":   map[string]any{"type": "Budget", "category": "Cost", "criteria": "CostThresholdExceeded"},
+			"description":  "Actual cost exceeded 80% of the monthly budget",
+			"source":       "User",
+			"status":       "Active",
+			"costEntityId": "monthly",
+			"creationTime": "2030-01-20T08:00:00Z",
+			"details": map[string]any{
+				"amount":        100,
+				"currentSpend":  85.5,
+				"threshold":     0.8,
+				"operator":      "GreaterThan",
+				"unit":          "USD",
+				"timeGrainType": "Monthly",
+				"contactEmails": []string{"finops@example.com"},
+			},
+		},
+	},
+	{
+		"name": "budget-forecast-100",
+		"properties": map[string]any{
+			"definition": map[string]any{"type": "BudgetForecast", "category": "Cost", "criteria": "ForecastCostThresholdExceeded"},
+			"source":     "User",
+			"status":     "Active",
+		},
+	},
+	{
+		"name": "quota",
+		"properties": map[string]any{
+			"definition": map[string]any{"type": "Quota", "category": "Usage", "criteria": "QuotaThresholdReached"},
+			"source":     "Preset",
+			"status":     "Dismissed",
+		},
+	},
+}
+
+// fakeAlertAt returns the alert named name at scope, nil if there is none.
+func (f *fakeARM) fakeAlertAt(scope string, name string) map[string]any {
+	id := scope + "/providers/Microsoft.CostManagement/alerts/" + name
+	if alert, ok := f.costResources[strings.ToLower(id)]; ok {
+		return alert
+	}
+	for _, alert := range fakeAlerts {
+		if strings.EqualFold(alert["name"].(string), name) {
+			alert = copyCostResource(alert)
+			alert["id"] = id
+			alert["type"] = "Microsoft.CostManagement/alerts"
+			return alert
+		}
+	}
+	return nil
+}
+
+func (f *fakeARM) listAlerts(w http.ResponseWriter, r *http.Request, match []string) {
+	alerts := make([]map[string]any, 0, len(fakeAlerts))
+	for _, alert := range fakeAlerts {
+		alerts = append(alerts, f.fakeAlertAt(match[1], alert["name"].(string)))
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"value": alerts})
+}
+
+func (f *fakeARM) getAlert(w http.ResponseWriter, r *http.Request, match []string) {
+	alert := f.fakeAlertAt(match[1], match[2])
+	if alert == nil {
+		writeARMError(w, http.StatusNotFound, "NotFound", fmt.Sprintf("Alert '%s' was not found.", match[2]))
+		return
+	}
+	writeJSON(w, http.StatusOK, alert)
+}
+
+// patchAlert updates the status of an alert, the only property Cost
+// Management lets callers change.
+func (f *fakeARM) patchAlert(w http.ResponseWriter, r *http.Request, match []string) {
+	var body struct {
+		Properties struct {
+			Status string `json:"status"`
+		} `json:"properties"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeARMError(w, http.StatusBadRequest, "InvalidRequestContent", err.Error())
+		return
+	}
+
+	alert := f.fakeAlertAt(match[1], match[2])
+	if alert == nil {
+		writeARMError(w, http.StatusNotFound, "NotFound", fmt.Sprintf("Alert '%s' was not found.", match[2]))
+		return
+	}
+	alert = copyCostResource(alert)
+	properties := alert["properties"].(map[string]any)
+	properties["status"] = body.Properties.Status
+	properties["statusModificationTime"] = time.Now().UTC().Format(time.RFC3339)
+	properties["statusModificationUserName"] = "terraform@example.com"
+	f.costResources[strings.ToLower(alert["id"].(string))] = alert
+
+	writeJSON(w, http.StatusOK, alert)
+}
+
+// fakeCostDetailsPolls is how often a cost details operation is polled
+// before it completes.
+const fakeCostDetailsPolls = 2
+
+// generateCostDetailsReport accepts a report like Cost Management does,
+// answering 202 with a Location to poll. The report has data unless its
+// time period ends before or starts after fakeUsage.
+func (f *fakeARM) generateCostDetailsReport(w http.ResponseWriter, r *http.Request, match []string) {
+	var body struct {
+		Metric     string `json:"metric"`
+		TimePeriod *struct {
+			Start string `json:"start"`
+			End   string `json:"end"`
+		} `json:"timePeriod"`
+		BillingPeriod string `json:"billingPeriod"`
+		InvoiceID     string `json:"invoiceId"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeARMError(w, http.StatusBadRequest, "InvalidRequestContent", err.Error())
+		return
+	}
+	if body.Metric == "" {
+		writeARMError(w, http.StatusBadRequest, "BadRequest", "The metric is required.")
+		return
+	}
+
+	status := "Completed"
+	if body.TimePeriod != nil {
+		first, last := strconv.Itoa(fakeUsage[0].date), strconv.Itoa(fakeUsage[len(fakeUsage)-1].date)
+		if strings.ReplaceAll(body.TimePeriod.End, "-", "") < first || strings.ReplaceAll(body.TimePeriod.Start, "-", "") > last {
+			status = "NoDataFound"
+		}
+	}
+
+	name := fmt.Sprintf("%d", time.Now().UnixNano())
+	path := match[1] + "/providers/Microsoft.CostManagement/costDetailsOperationResults/" + name
+	operation := map[string]any{
+		"id":     path,
+		"name":   name,
+		"type":   "Microsoft.CostManagement/costDetailsOperationResults",
+		"status": status,
+		"polls":  0,
+	}
+	if status == "Completed" {
+		operation["manifest"] = map[string]any{
+			"manifestVersion": "2022-05-01",
+			"dataFormat":      "Csv",
+			"blobCount":       2,
+			"byteCount":       300,
+			"blobs": []map[string]any{
+				{"blobLink": f.server.URL + "/reports/" + name + "/part0.csv?sig=fake", "byteCount": 200},
+				{"blobLink": f.server.URL + "/reports/" + name + "/part1.csv?sig=fake", "byteCount": 100},
+			},
+		}
+		operation["validTill"] = "2030-01-02T00:00:00Z"
+	}
+	f.costResources[strings.ToLower(path)] = operation
+
+	w.Header().Set("Location", f.server.URL+path+"?api-version=2023-03-01")
+	w.Header().Set("Retry-After", "0")
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (f *fakeARM) getCostDetailsOperation(w http.ResponseWriter, r *http.Request, match []string) {
+	operation, ok := f.costResources[costResourceKey(r, "")]
+	if !ok {
+		writeARMError(w, http.StatusNotFound, "NotFound", fmt.Sprintf("Operation '%s' was not found.", match[2]))
+		return
+	}
+
+	operation["polls"] = operation["polls"].(int) + 1
+	if operation["polls"].(int) < fakeCostDetailsPolls {
+		w.Header().Set("Location", f.server.URL+r.URL.Path+"?api-version=2023-03-01")
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	result := copyCostResource(operation)
+	delete(result, "polls")
+	writeJSON(w, http.StatusOK, result)
+}
+
+// fakeBenefitFilter matches the conditions of a benefitRecommendations $filter.
+var fakeBenefitFilter = regexp.MustCompile(`properties/(\w+) eq '([^']*)'`)
+
+// listBenefitRecommendations returns one savings plan recommendation for the
+// look-back period, term and scope of the $filter, using the defaults of
+// Cost Management for conditions that are not set.
+func (f *fakeARM) listBenefitRecommendations(w http.ResponseWriter, r *http.Request, match []string) {
+	conditions := map[string]string{"lookBackPeriod": "Last60Days", "term": "P3Y", "scope": "Shared"}
+	for _, condition := range fakeBenefitFilter.FindAllStringSubmatch(r.URL.Query().Get("$filter"), -1) {
+		if _, ok := conditions[condition[1]]; !ok {
+			writeARMError(w, http.StatusBadRequest, "BadRequest", fmt.Sprintf("Filtering by '%s' is not supported.", condition[1]))
+			return
+		}
+		conditions[condition[1]] = condition[2]
+	}
+
+	commitment := 1.0
+	if conditions["term"] == "P1Y" {
+		commitment = 1.5
+	}
+	name := strings.ToLower(conditions["scope"] + "-" + conditions["term"] + "-" + conditions["lookBackPeriod"])
+	properties := map[string]any{
+		"scope":                 conditions["scope"],
+		"term":                  conditions["term"],
+		"lookBackPeriod":        conditions["lookBackPeriod"],
+		"commitmentGranularity": "Hourly",
+		"armSkuName":            "Compute_Savings_Plan",
+		"currencyCode":          "USD",
+		"costWithoutBenefit":    2000,
+		"firstConsumptionDate":  "2029-11-01T00:00:00Z",
+		"lastConsumptionDate":   "2029-12-31T00:00:00Z",
+		"totalHours":            1440,
+		"recommendationDetails": map[string]any{
+			"commitmentAmount":             commitment,
+			"benefitCost":                  commitment * 1440,
+			"totalCost":                    1600,
+			"savingsAmount":                400,
+			"savingsPercentage":            20,
+			"coveragePercentage":           90,
+			"averageUtilizationPercentage": 98.5,
+			"overageCost":                  160,
+			"wastageCost":                  21.6,
+		},
+	}
+	if conditions["scope"] == "Single" {
+		properties["subscriptionId"] = fakeSubscriptionID
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"value": []map[string]any{{
+		"id":         match[1] + "/providers/Microsoft.CostManagement/benefitRecommendations/" + name,
+		"name":       name,
+		"type":       "Microsoft.CostManagement/benefitRecommendations",
+		"kind":       "SavingsPlan",
+		"properties": properties,
+	}}})
+}
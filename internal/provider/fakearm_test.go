@@ -0,0 +1,419 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+const fakeSubscriptionID = "00000000-0000-0000-0000-000000000000"
+
+// fakeARM is an in-process stand-in for the parts of Azure Resource Manager
+// the provider talks to, so resource.Test flows can run without an Azure
+// subscription. State is keyed by the lower-cased scope.
+type fakeARM struct {
+	t      *testing.T
+	server *httptest.Server
+	routes []fakeRoute
+
+	mu             sync.Mutex
+	subscriptions  map[string]bool
+	tags           map[string]map[string]string
+	tagInheritance map[string]bool
+	costResources  map[string]map[string]any
+	exportRuns     map[string][]map[string]any
+}
+
+type fakeRoute struct {
+	method  string
+	pattern *regexp.Regexp
+	handle  func(w http.ResponseWriter, r *http.Request, match []string)
+}
+
+// newFakeARM starts a fake ARM server that knows about fakeSubscriptionID.
+func newFakeARM(t *testing.T) *fakeARM {
+	t.Helper()
+
+	f := &fakeARM{
+		t:              t,
+		subscriptions:  map[string]bool{fakeSubscriptionID: true},
+		tags:           make(map[string]map[string]string),
+		tagInheritance: make(map[string]bool),
+		costResources:  make(map[string]map[string]any),
+		exportRuns:     make(map[string][]map[string]any),
+	}
+
+	const scope = `(/.+?)`
+	f.routes = []fakeRoute{
+		{http.MethodGet, regexp.MustCompile(`(?i)^/subscriptions()$`), f.listSubscriptions},
+		{http.MethodGet, regexp.MustCompile(`(?i)^/subscriptions/([^/]+)$`), f.getSubscription},
+		{http.MethodGet, regexp.MustCompile(`(?i)^` + scope + `/providers/Microsoft\.Resources/tags/default$`), f.getTags},
+		{http.MethodPut, regexp.MustCompile(`(?i)^` + scope + `/providers/Microsoft\.Resources/tags/default$`), f.putTags},
+		{http.MethodPatch, regexp.MustCompile(`(?i)^` + scope + `/providers/Microsoft\.Resources/tags/default$`), f.patchTags},
+		{http.MethodDelete, regexp.MustCompile(`(?i)^` + scope + `/providers/Microsoft\.Resources/tags/default$`), f.deleteTags},
+		{http.MethodGet, regexp.MustCompile(`(?i)^` + scope + `/providers/Microsoft\.CostManagement/settings/taginheritance$`), f.getTagInheritance},
+		{http.MethodPut, regexp.MustCompile(`(?i)^` + scope + `/providers/Microsoft\.CostManagement/settings/taginheritance$`), f.putTagInheritance},
+		{http.MethodDelete, regexp.MustCompile(`(?i)^` + scope + `/providers/Microsoft\.CostManagement/settings/taginheritance$`), f.deleteTagInheritance},
+	}
+	f.routes = append(f.routes, f.costRoutes(scope)...)
+
+	f.server = httptest.NewTLSServer(http.HandlerFunc(f.serveHTTP))
+	t.Cleanup(f.server.Close)
+
+	return f
+}
+
+// clientOptions returns ARM client options sending every request to the
+// fake server.
+func (f *fakeARM) clientOptions() *arm.ClientOptions {
+	return &arm.ClientOptions{
+		ClientOptions: policy.ClientOptions{
+			Cloud: cloud.Configuration{
+				ActiveDirectoryAuthorityHost: f.server.URL,
+				Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+					cloud.ResourceManager: {Endpoint: f.server.URL, Audience: f.server.URL},
+				},
+			},
+			Retry:     policy.RetryOptions{MaxRetries: -1},
+			Transport: f.server.Client(),
+		},
+	}
+}
+
+// providerData returns the provider data a configured provider hands to its
+// resources, for tests calling resource methods without the Terraform CLI.
+func (f *fakeARM) providerData(subscriptionID string) AzurexContext {
+	return AzurexContext{
+		SubscriptionID: subscriptionID,
+		ClientOptions:  f.clientOptions(),
+		IdentityCreds:  staticCredential{token: "fake"},
+	}
+}
+
+// providerFactories returns provider factories wired to the fake server.
+func (f *fakeARM) providerFactories() map[string]func() (tfprotov6.ProviderServer, error) {
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"azurex": providerserver.NewProtocol6WithError(New("test",
+			WithClientOptions(f.clientOptions()),
+			WithCredential(staticCredential{token: "fake"}),
+		)()),
+	}
+}
+
+// providerConfig is the provider block every fake ARM test config starts with.
+func (f *fakeARM) providerConfig() string {
+	return f.providerConfigWith("")
+}
+
+// providerConfigWith returns the provider block with extra attributes, e.g.
+// default_tags.
+func (f *fakeARM) providerConfigWith(attributes string) string {
+	return fmt.Sprintf(`
+provider "azurex" {
+  subscription_id = %q
+  %s
+}
+`, fakeSubscriptionID, attributes)
+}
+
+func (f *fakeARM) setTags(scope string, tags map[string]string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.tags[fakeScopeKey(scope)] = copyTags(tags)
+}
+
+func (f *fakeARM) getTagsAt(scope string) map[string]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return copyTags(f.tags[fakeScopeKey(scope)])
+}
+
+func (f *fakeARM) setTagInheritance(scope string, enabled bool, preferContainerTags bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if enabled {
+		f.tagInheritance[fakeScopeKey(scope)] = preferContainerTags
+	} else {
+		delete(f.tagInheritance, fakeScopeKey(scope))
+	}
+}
+
+func (f *fakeARM) tagInheritanceAt(scope string) (enabled bool, preferContainerTags bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	preferContainerTags, enabled = f.tagInheritance[fakeScopeKey(scope)]
+	return enabled, preferContainerTags
+}
+
+func (f *fakeARM) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+		writeARMError(w, http.StatusUnauthorized, "AuthenticationFailed", "Authentication failed. The 'Authorization' header is missing.")
+		return
+	}
+	if r.URL.Query().Get("api-version") == "" {
+		writeARMError(w, http.StatusBadRequest, "MissingApiVersionParameter", "The api-version query parameter (?api-version=) is required for all requests.")
+		return
+	}
+
+	for _, route := range f.routes {
+		if route.method != r.Method {
+			continue
+		}
+		if match := route.pattern.FindStringSubmatch(r.URL.Path); match != nil {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+
+			if subscriptionID := fakeSubscriptionOf(match[1]); subscriptionID != "" && !f.subscriptions[subscriptionID] {
+				writeARMError(w, http.StatusNotFound, "SubscriptionNotFound", fmt.Sprintf("The subscription '%s' could not be found.", subscriptionID))
+				return
+			}
+
+			route.handle(w, r, match)
+			return
+		}
+	}
+
+	f.t.Logf("fake ARM: no route for %s %s", r.Method, r.URL.Path)
+	writeARMError(w, http.StatusNotFound, "NotFound", fmt.Sprintf("No route for %s %s.", r.Method, r.URL.Path))
+}
+
+func (f *fakeARM) addSubscription(subscriptionID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.subscriptions[strings.ToLower(subscriptionID)] = true
+}
+
+func (f *fakeARM) subscriptionResource(subscriptionID string) map[string]any {
+	return map[string]any{
+		"id":             "/subscriptions/" + subscriptionID,
+		"subscriptionId": subscriptionID,
+		"displayName":    "fake subscription " + subscriptionID,
+		"state":          "Enabled",
+	}
+}
+
+func (f *fakeARM) listSubscriptions(w http.ResponseWriter, _ *http.Request, _ []string) {
+	ids := make([]string, 0, len(f.subscriptions))
+	for id := range f.subscriptions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	value := make([]map[string]any, 0, len(ids))
+	for _, id := range ids {
+		value = append(value, f.subscriptionResource(id))
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"value": value})
+}
+
+func (f *fakeARM) getSubscription(w http.ResponseWriter, _ *http.Request, match []string) {
+	if !f.subscriptions[strings.ToLower(match[1])] {
+		writeARMError(w, http.StatusNotFound, "SubscriptionNotFound", fmt.Sprintf("The subscription '%s' could not be found.", match[1]))
+		return
+	}
+	writeJSON(w, http.StatusOK, f.subscriptionResource(match[1]))
+}
+
+func (f *fakeARM) tagsResource(scope string) map[string]any {
+	tags := f.tags[fakeScopeKey(scope)]
+	if tags == nil {
+		tags = map[string]string{}
+	}
+	return map[string]any{
+		"id":   scope + "/providers/Microsoft.Resources/tags/default",
+		"name": "default",
+		"type": "Microsoft.Resources/tags",
+		"properties": map[string]any{
+			"tags": tags,
+		},
+	}
+}
+
+func (f *fakeARM) getTags(w http.ResponseWriter, _ *http.Request, match []string) {
+	writeJSON(w, http.StatusOK, f.tagsResource(match[1]))
+}
+
+func (f *fakeARM) putTags(w http.ResponseWriter, r *http.Request, match []string) {
+	var body struct {
+		Properties struct {
+			Tags map[string]string `json:"tags"`
+		} `json:"properties"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeARMError(w, http.StatusBadRequest, "InvalidRequestContent", err.Error())
+		return
+	}
+
+	f.tags[fakeScopeKey(match[1])] = copyTags(body.Properties.Tags)
+	writeJSON(w, http.StatusOK, f.tagsResource(match[1]))
+}
+
+func (f *fakeARM) patchTags(w http.ResponseWriter, r *http.Request, match []string) {
+	var body struct {
+		Operation  string `json:"operation"`
+		Properties struct {
+			Tags map[string]string `json:"tags"`
+		} `json:"properties"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeARMError(w, http.StatusBadRequest, "InvalidRequestContent", err.Error())
+		return
+	}
+
+	key := fakeScopeKey(match[1])
+	current := copyTags(f.tags[key])
+	switch body.Operation {
+	case "Replace":
+		current = copyTags(body.Properties.Tags)
+	case "Merge":
+		for k, v := range body.Properties.Tags {
+			current[k] = v
+		}
+	case "Delete":
+		for k, v := range body.Properties.Tags {
+			if current[k] == v {
+				delete(current, k)
+			}
+		}
+	default:
+		writeARMError(w, http.StatusBadRequest, "InvalidTagPatchOperation", fmt.Sprintf("The operation '%s' is not supported.", body.Operation))
+		return
+	}
+	f.tags[key] = current
+
+	writeJSON(w, http.StatusOK, f.tagsResource(match[1]))
+}
+
+func (f *fakeARM) deleteTags(w http.ResponseWriter, _ *http.Request, match []string) {
+	delete(f.tags, fakeScopeKey(match[1]))
+	w.WriteHeader(http.StatusOK)
+}
+
+func (f *fakeARM) tagInheritanceResource(scope string, preferContainerTags bool) map[string]any {
+	return map[string]any{
+		"id":   scope + tagInheritanceSettingsPath,
+		"name": "taginheritance",
+		"type": "Microsoft.CostManagement/Settings",
+		"kind": "taginheritance",
+		"properties": map[string]any{
+			"preferContainerTags": preferContainerTags,
+		},
+	}
+}
+
+func (f *fakeARM) getTagInheritance(w http.ResponseWriter, _ *http.Request, match []string) {
+	preferContainerTags, ok := f.tagInheritance[fakeScopeKey(match[1])]
+	if !ok {
+		writeARMError(w, http.StatusNotFound, "NotFound", "Setting 'taginheritance' was not found.")
+		return
+	}
+	writeJSON(w, http.StatusOK, f.tagInheritanceResource(match[1], preferContainerTags))
+}
+
+func (f *fakeARM) putTagInheritance(w http.ResponseWriter, r *http.Request, match []string) {
+	var body struct {
+		Kind       string `json:"kind"`
+		Properties struct {
+			PreferContainerTags bool `json:"preferContainerTags"`
+		} `json:"properties"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeARMError(w, http.StatusBadRequest, "InvalidRequestContent", err.Error())
+		return
+	}
+	if body.Kind != "taginheritance" {
+		writeARMError(w, http.StatusBadRequest, "InvalidSettingKind", fmt.Sprintf("The setting kind '%s' is not supported.", body.Kind))
+		return
+	}
+
+	key := fakeScopeKey(match[1])
+	_, existed := f.tagInheritance[key]
+	f.tagInheritance[key] = body.Properties.PreferContainerTags
+
+	status := http.StatusCreated
+	if existed {
+		status = http.StatusOK
+	}
+	writeJSON(w, status, f.tagInheritanceResource(match[1], body.Properties.PreferContainerTags))
+}
+
+func (f *fakeARM) deleteTagInheritance(w http.ResponseWriter, _ *http.Request, match []string) {
+	key := fakeScopeKey(match[1])
+	if _, ok := f.tagInheritance[key]; !ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	delete(f.tagInheritance, key)
+	w.WriteHeader(http.StatusOK)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeARMError(w http.ResponseWriter, status int, code string, message string) {
+	writeJSON(w, status, map[string]any{
+		"error": map[string]any{
+			"code":    code,
+			"message": message,
+		},
+	})
+}
+
+func fakeScopeKey(scope string) string {
+	return strings.ToLower(strings.TrimSuffix(scope, "/"))
+}
+
+// fakeSubscriptionOf returns the subscription ID a scope lives in, or "" for
+// scopes outside a subscription such as billing accounts.
+func fakeSubscriptionOf(scope string) string {
+	parts := strings.Split(strings.Trim(scope, "/"), "/")
+	if len(parts) >= 2 && strings.EqualFold(parts[0], "subscriptions") {
+		return strings.ToLower(parts[1])
+	}
+	return ""
+}
+
+func copyTags(tags map[string]string) map[string]string {
+	copied := make(map[string]string, len(tags))
+	for k, v := range tags {
+		copied[k] = v
+	}
+	return copied
+}
+
+// skipWithoutTerraform skips tests that drive the Terraform CLI unless
+// TF_ACC is set, as by make testacc, so go test and make test stay offline.
+// With TF_ACC set a missing CLI fails the test rather than skipping it.
+func skipWithoutTerraform(t *testing.T) {
+	t.Helper()
+
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("fake ARM acceptance tests run with TF_ACC=1, e.g. make testacc")
+	}
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") != "" || os.Getenv("TF_ACC_TERRAFORM_VERSION") != "" {
+		return
+	}
+	if _, err := exec.LookPath("terraform"); err != nil {
+		t.Fatal("terraform CLI not found, install it or set TF_ACC_TERRAFORM_PATH or TF_ACC_TERRAFORM_VERSION")
+	}
+}
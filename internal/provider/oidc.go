@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// githubOIDCAudience is the audience Entra ID expects on federated tokens.
+	githubOIDCAudience = "api://AzureADTokenExchange"
+
+	adoPipelineOIDCAPIVersion = "7.1"
+)
+
+// oidcTokenRequester fetches a fresh ID token from the CI system every time
+// Entra ID needs a client assertion, so applies can outlive a single token.
+type oidcTokenRequester struct {
+	requestURL   string
+	requestToken string
+
+	// serviceConnectionID selects the Azure DevOps flow when set, otherwise
+	// the GitHub Actions flow is used.
+	serviceConnectionID string
+
+	client *http.Client
+}
+
+func (r *oidcTokenRequester) token(ctx context.Context) (string, error) {
+	req, err := r.newRequest(ctx)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+r.requestToken)
+
+	client := r.client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("requesting oidc token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("reading oidc token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("requesting oidc token: unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var payload struct {
+		// Value is returned by GitHub Actions.
+		Value string `json:"value"`
+		// OIDCToken is returned by Azure DevOps.
+		OIDCToken string `json:"oidcToken"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return "", fmt.Errorf("decoding oidc token response: %w", err)
+	}
+
+	token := payload.Value
+	if r.serviceConnectionID != "" {
+		token = payload.OIDCToken
+	}
+	if token == "" {
+		return "", fmt.Errorf("oidc token response did not contain a token")
+	}
+
+	return token, nil
+}
+
+func (r *oidcTokenRequester) newRequest(ctx context.Context) (*http.Request, error) {
+	u, err := url.Parse(r.requestURL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing oidc request url: %w", err)
+	}
+	query := u.Query()
+
+	method := http.MethodGet
+	if r.serviceConnectionID != "" {
+		method = http.MethodPost
+		query.Set("api-version", adoPipelineOIDCAPIVersion)
+		query.Set("serviceConnectionId", r.serviceConnectionID)
+	} else {
+		query.Set("audience", githubOIDCAudience)
+	}
+	u.RawQuery = query.Encode()
+
+	return http.NewRequestWithContext(ctx, method, u.String(), http.NoBody)
+}
@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// newTestOIDCServer stands in for the GitHub Actions and Azure DevOps token
+// endpoints, issuing a new token on every request.
+func newTestOIDCServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	issued := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer request-token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		issued[r.URL.Path]++
+
+		query := r.URL.Query()
+		switch {
+		case r.URL.Path == "/github" && r.Method == http.MethodGet && query.Get("audience") == githubOIDCAudience:
+			_ = json.NewEncoder(w).Encode(map[string]string{"value": fmt.Sprintf("github-token-%d", issued[r.URL.Path])})
+		case r.URL.Path == "/ado" && r.Method == http.MethodPost && query.Get("serviceConnectionId") == "connection" && query.Get("api-version") == adoPipelineOIDCAPIVersion:
+			_ = json.NewEncoder(w).Encode(map[string]string{"oidcToken": fmt.Sprintf("ado-token-%d", issued[r.URL.Path])})
+		default:
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestOIDCTokenRequester(t *testing.T) {
+	server := newTestOIDCServer(t)
+
+	cases := map[string]struct {
+		requester oidcTokenRequester
+		want      []string
+		err       string
+	}{
+		"github": {
+			requester: oidcTokenRequester{requestURL: server.URL + "/github", requestToken: "request-token"},
+			want:      []string{"github-token-1", "github-token-2"},
+		},
+		"ado": {
+			requester: oidcTokenRequester{requestURL: server.URL + "/ado", requestToken: "request-token", serviceConnectionID: "connection"},
+			want:      []string{"ado-token-1", "ado-token-2"},
+		},
+		"unauthorized": {
+			requester: oidcTokenRequester{requestURL: server.URL + "/github", requestToken: "wrong"},
+			err:       "unexpected status 401",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if tc.err != "" {
+				_, err := tc.requester.token(context.Background())
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("expected error containing %q, got: %v", tc.err, err)
+				}
+				return
+			}
+
+			// every call must fetch a fresh token rather than reuse the first
+			for _, want := range tc.want {
+				got, err := tc.requester.token(context.Background())
+				if err != nil {
+					t.Fatalf("requesting token: %s", err)
+				}
+				if got != want {
+					t.Fatalf("expected token %q, got %q", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestProviderConfig_useOIDC(t *testing.T) {
+	config := newProviderConfig(AzurexProviderModel{
+		SubscriptionID: types.StringValue("00000000-0000-0000-0000-000000000000"),
+		TenantID:       types.StringValue("00000000-0000-0000-0000-000000000001"),
+		ClientID:       types.StringValue("00000000-0000-0000-0000-000000000002"),
+		UseOIDC:        types.BoolValue(true),
+	})
+	config.OIDCRequestURL = ""
+	config.OIDCRequestToken = ""
+
+	if method := config.authMethod(); method != authMethodGitHubOIDC {
+		t.Fatalf("expected github oidc authentication, got %q", method)
+	}
+	if diags := config.validate(); diags.ErrorsCount() != 2 {
+		t.Fatalf("expected missing oidc_request_url and oidc_request_token errors, got: %v", diags)
+	}
+
+	config.ADOPipelineServiceConnectionID = "connection"
+	if method := config.authMethod(); method != authMethodADOPipelineOIDC {
+		t.Fatalf("expected azure devops oidc authentication, got %q", method)
+	}
+}
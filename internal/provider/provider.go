@@ -6,22 +6,22 @@ package provider
 import (
 	"context"
 	"fmt"
-	"os"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
-	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/hashicorp/go-azure-sdk/sdk/auth"
 	"github.com/hashicorp/go-azure-sdk/sdk/auth/autorest"
-	"github.com/hashicorp/go-azure-sdk/sdk/environments"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
 // Ensure AzurexProvider satisfies various provider interfaces.
@@ -35,6 +35,30 @@ type AzurexProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+
+	// clientOptions and credential replace the ARM client options and the
+	// credential built from the provider configuration when set, see Option.
+	clientOptions *arm.ClientOptions
+	credential    azcore.TokenCredential
+}
+
+// Option customises the provider returned by New.
+type Option func(*AzurexProvider)
+
+// WithClientOptions makes every ARM client use options instead of the ones
+// derived from the environment, e.g. to send requests to a local server.
+func WithClientOptions(options *arm.ClientOptions) Option {
+	return func(p *AzurexProvider) {
+		p.clientOptions = options
+	}
+}
+
+// WithCredential authenticates every request with credential and skips the
+// authentication attributes of the provider configuration.
+func WithCredential(credential azcore.TokenCredential) Option {
+	return func(p *AzurexProvider) {
+		p.credential = credential
+	}
 }
 
 // AzurexProviderModel describes the provider data model.
@@ -43,11 +67,40 @@ type AzurexProviderModel struct {
 	TenantID       types.String `tfsdk:"tenant_id"`
 	ClientID       types.String `tfsdk:"client_id"`
 	ClientSecret   types.String `tfsdk:"client_secret"`
+
+	ClientCertificate         types.String `tfsdk:"client_certificate"`
+	ClientCertificatePath     types.String `tfsdk:"client_certificate_path"`
+	ClientCertificatePassword types.String `tfsdk:"client_certificate_password"`
+	OIDCToken                 types.String `tfsdk:"oidc_token"`
+	OIDCTokenFilePath         types.String `tfsdk:"oidc_token_file_path"`
+	UseMSI                    types.Bool   `tfsdk:"use_msi"`
+	MSIEndpoint               types.String `tfsdk:"msi_endpoint"`
+	UseCLI                    types.Bool   `tfsdk:"use_cli"`
+	Environment               types.String `tfsdk:"environment"`
+	MetadataHost              types.String `tfsdk:"metadata_host"`
+
+	UseOIDC                        types.Bool   `tfsdk:"use_oidc"`
+	OIDCRequestURL                 types.String `tfsdk:"oidc_request_url"`
+	OIDCRequestToken               types.String `tfsdk:"oidc_request_token"`
+	ADOPipelineServiceConnectionID types.String `tfsdk:"ado_pipeline_service_connection_id"`
+
+	DefaultTags types.Map    `tfsdk:"default_tags"`
+	IgnoreTags  types.Object `tfsdk:"ignore_tags"`
+}
+
+// ignoreTagsModel describes the ignore_tags attribute.
+type ignoreTagsModel struct {
+	Keys        types.Set `tfsdk:"keys"`
+	KeyPrefixes types.Set `tfsdk:"key_prefixes"`
 }
 
 type AzurexContext struct {
 	SubscriptionID string
 
+	// ClientOptions carries the cloud configuration every ARM client must be
+	// created with.
+	ClientOptions *arm.ClientOptions
+
 	Graph      *autorest.Authorizer
 	Management *autorest.Authorizer
 
@@ -55,6 +108,10 @@ type AzurexContext struct {
 	ResourceManager auth.Authorizer
 
 	IdentityCreds azcore.TokenCredential
+
+	// TagsSettings holds default_tags and ignore_tags, applied by every
+	// resource managing tags.
+	TagsSettings tagsSettings
 }
 
 func (p *AzurexProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -64,24 +121,111 @@ func (p *AzurexProvider) Metadata(ctx context.Context, req provider.MetadataRequ
 
 func (p *AzurexProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		MarkdownDescription: "Values set in the provider block take precedence over environment variables. " +
+			"When several authentication methods are configured, a client certificate is preferred over a client secret, " +
+			"which is preferred over an OIDC token, an OIDC token requested with `use_oidc`, managed identity and then the Azure CLI. " +
+			"When no authentication method is configured, environment, workload identity, managed identity and " +
+			"Azure CLI credentials are tried in that order.",
+
 		Attributes: map[string]schema.Attribute{
 			"subscription_id": schema.StringAttribute{
-				MarkdownDescription: "Azure Subscription ID",
+				MarkdownDescription: "Azure Subscription ID used by resources that do not set their own. Can also be sourced from `ARM_SUBSCRIPTION_ID`.",
 				Optional:            true,
 			},
 			"tenant_id": schema.StringAttribute{
-				MarkdownDescription: "Tenant ID",
+				MarkdownDescription: "Tenant ID. Can also be sourced from `ARM_TENANT_ID` or `AZURE_TENANT_ID`.",
 				Optional:            true,
 			},
 			"client_id": schema.StringAttribute{
-				MarkdownDescription: "SettingsClient ID",
+				MarkdownDescription: "Client ID of the service principal, or of the user-assigned identity when using managed identity. Can also be sourced from `ARM_CLIENT_ID` or `AZURE_CLIENT_ID`.",
 				Optional:            true,
 			},
 			"client_secret": schema.StringAttribute{
-				MarkdownDescription: "SettingsClient Secret",
+				MarkdownDescription: "Client Secret of the service principal. Can also be sourced from `ARM_CLIENT_SECRET`.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"client_certificate": schema.StringAttribute{
+				MarkdownDescription: "Base64 encoded PKCS#12 (.pfx) or PEM client certificate, conflicts with `client_certificate_path`. Can also be sourced from `ARM_CLIENT_CERTIFICATE`.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"client_certificate_path": schema.StringAttribute{
+				MarkdownDescription: "Path to a PKCS#12 (.pfx) or PEM client certificate. Can also be sourced from `ARM_CLIENT_CERTIFICATE_PATH` or `ARM_CLIENT_CERTIFICATE_FILE`.",
+				Optional:            true,
+			},
+			"client_certificate_password": schema.StringAttribute{
+				MarkdownDescription: "Password protecting the client certificate. Can also be sourced from `ARM_CLIENT_CERTIFICATE_PASSWORD`.",
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"oidc_token": schema.StringAttribute{
+				MarkdownDescription: "OIDC token used for federated authentication. Can also be sourced from `ARM_OIDC_TOKEN`.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"oidc_token_file_path": schema.StringAttribute{
+				MarkdownDescription: "Path to a file containing an OIDC token used for federated authentication. Can also be sourced from `ARM_OIDC_TOKEN_FILE_PATH` or `AZURE_FEDERATED_TOKEN_FILE`.",
+				Optional:            true,
+			},
+			"use_oidc": schema.BoolAttribute{
+				MarkdownDescription: "Authenticate using an OIDC token requested from GitHub Actions or Azure DevOps. Can also be sourced from `ARM_USE_OIDC`.",
+				Optional:            true,
+			},
+			"oidc_request_url": schema.StringAttribute{
+				MarkdownDescription: "URL used to request an OIDC token. Can also be sourced from `ARM_OIDC_REQUEST_URL`, `ACTIONS_ID_TOKEN_REQUEST_URL` or `SYSTEM_OIDCREQUESTURI`.",
+				Optional:            true,
+			},
+			"oidc_request_token": schema.StringAttribute{
+				MarkdownDescription: "Bearer token used to request an OIDC token. Can also be sourced from `ARM_OIDC_REQUEST_TOKEN`, `ACTIONS_ID_TOKEN_REQUEST_TOKEN` or `SYSTEM_ACCESSTOKEN`.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"ado_pipeline_service_connection_id": schema.StringAttribute{
+				MarkdownDescription: "Azure DevOps service connection ID, selects the Azure DevOps OIDC flow instead of GitHub Actions. Can also be sourced from `ARM_ADO_PIPELINE_SERVICE_CONNECTION_ID` or `ARM_OIDC_AZURE_SERVICE_CONNECTION_ID`.",
+				Optional:            true,
+			},
+			"use_msi": schema.BoolAttribute{
+				MarkdownDescription: "Authenticate using a system or user-assigned managed identity. Can also be sourced from `ARM_USE_MSI`.",
+				Optional:            true,
+			},
+			"msi_endpoint": schema.StringAttribute{
+				MarkdownDescription: "Custom endpoint used to obtain managed identity tokens instead of the Azure Instance Metadata Service. Can also be sourced from `ARM_MSI_ENDPOINT`.",
+				Optional:            true,
+			},
+			"use_cli": schema.BoolAttribute{
+				MarkdownDescription: "Authenticate using the account signed in to the Azure CLI. Can also be sourced from `ARM_USE_CLI`.",
+				Optional:            true,
+			},
+			"environment": schema.StringAttribute{
+				MarkdownDescription: "Azure cloud to connect to, one of `public`, `usgovernment` or `china`. Defaults to `public`. Can also be sourced from `ARM_ENVIRONMENT`.",
+				Optional:            true,
+			},
+			"metadata_host": schema.StringAttribute{
+				MarkdownDescription: "Hostname of the Azure Metadata Service used to discover cloud endpoints, takes precedence over `environment`. Can also be sourced from `ARM_METADATA_HOSTNAME`.",
+				Optional:            true,
+			},
+			"default_tags": schema.MapAttribute{
+				MarkdownDescription: "Tags applied by every resource managing tags, e.g. `azurex_subscription_tags`. Tags set on a resource take precedence.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"ignore_tags": schema.SingleNestedAttribute{
+				MarkdownDescription: "Tags added outside of Terraform that every resource managing tags neither reports as drift nor removes, unless set on the resource",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"keys": schema.SetAttribute{
+						MarkdownDescription: "Tag keys to ignore",
+						ElementType:         types.StringType,
+						Optional:            true,
+					},
+					"key_prefixes": schema.SetAttribute{
+						MarkdownDescription: "Tag key prefixes to ignore",
+						ElementType:         types.StringType,
+						Optional:            true,
+					},
+				},
+			},
 		},
 	}
 }
@@ -96,96 +240,57 @@ func (p *AzurexProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
-	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	config := newProviderConfig(data)
 
-	if v := os.Getenv("ARM_SUBSCRIPTION_ID"); v != "" {
-		data.SubscriptionID = types.StringValue(v)
-	}
-	if v := os.Getenv("ARM_TENANT_ID"); v != "" {
-		data.TenantID = types.StringValue(v)
-	}
+	resp.Diagnostics.Append(config.validate()...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	env, err := environments.FromName("global")
+	env, err := config.azureEnvironment(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("unable to set environment", fmt.Sprintf("got: %s", err.Error()))
 		return
 	}
 
-	credentials := auth.Credentials{
-		Environment: *env,
-		TenantID:    data.TenantID.ValueString(),
-		ClientID:    os.Getenv("ARM_CLIENT_ID"),
-
-		EnableAuthenticatingUsingClientSecret: true,
+	configuration, err := cloudConfiguration(*env)
+	if err != nil {
+		resp.Diagnostics.AddError("unable to set environment", fmt.Sprintf("got: %s", err.Error()))
+		return
 	}
+	providerContext.ClientOptions = armClientOptions(configuration)
 
-	if os.Getenv("ARM_CLIENT_SECRET") != "" {
-		tflog.Debug(ctx, "authentication type: client secret")
-		credentials.EnableAuthenticatingUsingClientSecret = true
-		credentials.ClientSecret = os.Getenv("ARM_CLIENT_SECRET")
-
-		creds, err := azidentity.NewClientSecretCredential(data.TenantID.ValueString(), os.Getenv("ARM_CLIENT_ID"), os.Getenv("ARM_CLIENT_SECRET"), &azidentity.ClientSecretCredentialOptions{})
-		if err != nil {
-			resp.Diagnostics.AddError("unable to configure credential", fmt.Sprintf("got: %s", err.Error()))
-			return
-		}
-		providerContext.IdentityCreds = creds
-	} else if os.Getenv("ARM_CLIENT_CERTIFICATE_FILE") != "" {
-		tflog.Debug(ctx, "authentication type: client certificate")
-		credentials.EnableAuthenticatingUsingClientCertificate = true
-		credentials.ClientCertificatePath = os.Getenv("ARM_CLIENT_CERTIFICATE_FILE")
+	if p.clientOptions != nil {
+		providerContext.ClientOptions = p.clientOptions
+	}
 
-		certData, err := os.ReadFile(os.Getenv("ARM_CLIENT_CERTIFICATE_FILE"))
-		if err != nil {
-			resp.Diagnostics.AddError("unable to configure credential", fmt.Sprintf("got: %s", err.Error()))
-			return
-		}
+	method := config.authMethod()
 
-		certs, pkey, err := azidentity.ParseCertificates(certData, nil)
-		if err != nil {
-			resp.Diagnostics.AddError("unable to configure credential", fmt.Sprintf("got: %s", err.Error()))
-			return
-		}
+	var credentials auth.Credentials
+	var creds azcore.TokenCredential
+	if p.credential != nil {
+		tflog.Debug(ctx, "authentication type: credential supplied by provider option")
+		method = authMethodDefault
+		creds = p.credential
+	} else {
+		tflog.Debug(ctx, fmt.Sprintf("authentication type: %s", method))
 
-		creds, err := azidentity.NewClientCertificateCredential(data.TenantID.ValueString(), os.Getenv("ARM_CLIENT_ID"), certs, pkey, &azidentity.ClientCertificateCredentialOptions{})
-		if err != nil {
-			resp.Diagnostics.AddError("unable to configure credential", fmt.Sprintf("got: %s", err.Error()))
-			return
-		}
-		providerContext.IdentityCreds = creds
-	} else if os.Getenv("AZURE_FEDERATED_TOKEN_FILE") != "" {
-		tflog.Debug(ctx, "authentication type: federated token")
-		token, err := os.ReadFile(os.Getenv("AZURE_FEDERATED_TOKEN_FILE"))
-		if err != nil {
-			resp.Diagnostics.AddError("unable to configure credential", fmt.Sprintf("got: %s", err.Error()))
-			return
-		}
-		credentials.EnableAuthenticationUsingOIDC = true
-		credentials.OIDCAssertionToken = string(token)
-
-		creds, err := azidentity.NewWorkloadIdentityCredential(&azidentity.WorkloadIdentityCredentialOptions{
-			TenantID:      data.TenantID.ValueString(),
-			ClientID:      os.Getenv("AZURE_CLIENT_ID"),
-			TokenFilePath: os.Getenv("AZURE_FEDERATED_TOKEN_FILE"),
-		})
+		credentials, creds, err = config.credentials(ctx, *env)
 		if err != nil {
 			resp.Diagnostics.AddError("unable to configure credential", fmt.Sprintf("got: %s", err.Error()))
 			return
 		}
-		providerContext.IdentityCreds = creds
 	}
+	providerContext.IdentityCreds = creds
 
-	graphAuthorizer, err := auth.NewAuthorizerFromCredentials(ctx, credentials, env.MicrosoftGraph)
+	graphAuthorizer, err := newAuthorizer(ctx, method, credentials, creds, env.MicrosoftGraph)
 	if err != nil {
 		resp.Diagnostics.AddError("unable to configure credential", fmt.Sprintf("got: %s", err.Error()))
 		return
 	}
 
-	mgmtAuthorizer, err := auth.NewAuthorizerFromCredentials(ctx, credentials, env.ResourceManager)
+	mgmtAuthorizer, err := newAuthorizer(ctx, method, credentials, creds, env.ResourceManager)
 	if err != nil {
 		resp.Diagnostics.AddError("unable to configure credential", fmt.Sprintf("got: %s", err.Error()))
 		return
@@ -194,15 +299,51 @@ func (p *AzurexProvider) Configure(ctx context.Context, req provider.ConfigureRe
 	providerContext.Management = autorest.AutorestAuthorizer(mgmtAuthorizer)
 	providerContext.Graph = autorest.AutorestAuthorizer(graphAuthorizer)
 
-	providerContext.SubscriptionID = data.SubscriptionID.ValueString()
+	providerContext.SubscriptionID = config.SubscriptionID
+
+	tagsSettings, diags := data.tagsSettings(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	providerContext.TagsSettings = tagsSettings
 
 	resp.DataSourceData = providerContext
 	resp.ResourceData = providerContext
 }
 
+// tagsSettings returns the default_tags and ignore_tags configuration.
+func (m AzurexProviderModel) tagsSettings(ctx context.Context) (tagsSettings, diag.Diagnostics) {
+	var settings tagsSettings
+	var diags diag.Diagnostics
+
+	if !m.DefaultTags.IsNull() && !m.DefaultTags.IsUnknown() {
+		diags.Append(m.DefaultTags.ElementsAs(ctx, &settings.defaults, false)...)
+	}
+
+	if !m.IgnoreTags.IsNull() && !m.IgnoreTags.IsUnknown() {
+		var ignore ignoreTagsModel
+		diags.Append(m.IgnoreTags.As(ctx, &ignore, basetypes.ObjectAsOptions{})...)
+		if !ignore.Keys.IsNull() && !ignore.Keys.IsUnknown() {
+			diags.Append(ignore.Keys.ElementsAs(ctx, &settings.ignore.keys, false)...)
+		}
+		if !ignore.KeyPrefixes.IsNull() && !ignore.KeyPrefixes.IsUnknown() {
+			diags.Append(ignore.KeyPrefixes.ElementsAs(ctx, &settings.ignore.prefixes, false)...)
+		}
+	}
+	return settings, diags
+}
+
 func (p *AzurexProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewSubscriptionTagsResource,
+		NewCostTagInheritanceResource,
+		NewTagsResource,
+		NewResourceGroupTagsResource,
+		NewCostExportResource,
+		NewCostScheduledActionResource,
+		NewCostViewResource,
+		NewCostAlertStatusResource,
 	}
 }
 
@@ -211,17 +352,29 @@ func (p *AzurexProvider) EphemeralResources(ctx context.Context) []func() epheme
 }
 
 func (p *AzurexProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewSubscriptionTagsDataSource,
+		NewSubscriptionsTagsDataSource,
+		NewCostQueryDataSource,
+		NewCostForecastDataSource,
+		NewCostAlertsDataSource,
+		NewCostDetailsReportDataSource,
+		NewBenefitRecommendationsDataSource,
+	}
 }
 
 func (p *AzurexProvider) Functions(ctx context.Context) []func() function.Function {
 	return []func() function.Function{}
 }
 
-func New(version string) func() provider.Provider {
+func New(version string, opts ...Option) func() provider.Provider {
 	return func() provider.Provider {
-		return &AzurexProvider{
+		p := &AzurexProvider{
 			version: version,
 		}
+		for _, opt := range opts {
+			opt(p)
+		}
+		return p
 	}
 }
@@ -0,0 +1,369 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	subscriptionSettings "github.com/ekristen/terraform-provider-azurex/internal/azure/subscriptions"
+)
+
+// resourceGroupIDPattern matches /subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}.
+var resourceGroupIDPattern = regexp.MustCompile(`(?i)^/subscriptions/([^/]+)/resourceGroups/([^/]+)/?$`)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ResourceGroupTagsResource{}
+var _ resource.ResourceWithImportState = &ResourceGroupTagsResource{}
+var _ resource.ResourceWithModifyPlan = &ResourceGroupTagsResource{}
+
+func NewResourceGroupTagsResource() resource.Resource {
+	return &ResourceGroupTagsResource{}
+}
+
+// ResourceGroupTagsResource defines the resource implementation.
+type ResourceGroupTagsResource struct {
+	TagsClient     *armresources.TagsClient
+	SubscriptionID string
+	TagsSettings   tagsSettings
+}
+
+// ResourceGroupTagsResourceModel describes the resource data model.
+type ResourceGroupTagsResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	SubscriptionID    types.String `tfsdk:"subscription_id"`
+	ResourceGroupName types.String `tfsdk:"resource_group_name"`
+	Tags              types.Map    `tfsdk:"tags"`
+	TagsAll           types.Map    `tfsdk:"tags_all"`
+	Mode              types.String `tfsdk:"mode"`
+	IgnoreTagKeys     types.Set    `tfsdk:"ignore_tag_keys"`
+	IgnoreTagPrefixes types.Set    `tfsdk:"ignore_tag_prefixes"`
+}
+
+func (r *ResourceGroupTagsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_resource_group_tags"
+}
+
+func (r *ResourceGroupTagsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resource Group Tags, tolerating tags added by Cost Management tag inheritance or Azure Policy",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Resource ID of the resource group",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"subscription_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the subscription holding the resource group, defaults to the provider subscription. Changing this forces a new resource to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"resource_group_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the resource group to tag. Changing this forces a new resource to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tags": schema.MapAttribute{
+				MarkdownDescription: "Tags to apply to the resource group",
+				ElementType:         types.StringType,
+				Required:            true,
+			},
+			"tags_all": schema.MapAttribute{
+				MarkdownDescription: "Tags applied to the resource group, including the provider `default_tags`",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"mode": schema.StringAttribute{
+				MarkdownDescription: "How tags are applied, either `authoritative` to replace every tag on the resource group, except ignored ones, or `merge` to only manage the keys set in `tags`. Defaults to `authoritative`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(tagsModeAuthoritative),
+				Validators: []validator.String{
+					stringvalidator.OneOf(tagsModes...),
+				},
+			},
+			"ignore_tag_keys": schema.SetAttribute{
+				MarkdownDescription: "Tag keys added outside of Terraform that are neither reported as drift nor removed, unless also set in `tags`",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"ignore_tag_prefixes": schema.SetAttribute{
+				MarkdownDescription: "Tag key prefixes added outside of Terraform that are neither reported as drift nor removed, unless also set in `tags`",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *ResourceGroupTagsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(AzurexContext)
+	if !ok {
+		resp.Diagnostics.AddError("unable to obtain provider data", "provider data not available")
+		return
+	}
+
+	tagsClient, err := armresources.NewTagsClient(data.SubscriptionID, data.IdentityCreds, data.ClientOptions)
+	if err != nil {
+		resp.Diagnostics.AddError("unable to configure tags client", fmt.Sprintf("got: %s", err.Error()))
+		return
+	}
+	r.TagsClient = tagsClient
+
+	r.SubscriptionID = data.SubscriptionID
+	r.TagsSettings = data.TagsSettings
+}
+
+func (r *ResourceGroupTagsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data *ResourceGroupTagsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	filter, diags := data.tagFilter(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(planTagsAll(ctx, r.TagsSettings.withIgnore(filter), &resp.Plan)...)
+}
+
+func (r *ResourceGroupTagsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *ResourceGroupTagsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "creating resource group tags resource")
+
+	if data.SubscriptionID.IsUnknown() || data.SubscriptionID.IsNull() {
+		data.SubscriptionID = types.StringValue(r.SubscriptionID)
+	}
+	if data.SubscriptionID.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("subscription_id"), "Missing subscription ID",
+			"The subscription_id attribute must be set when the provider has no subscription_id configured.")
+		return
+	}
+
+	tfTags := make(map[string]string)
+	resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tfTags, false)...)
+	filter, diags := data.tagFilter(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings := r.TagsSettings.withIgnore(filter)
+	tagsAll := settings.all(tfTags)
+	scope := data.scope()
+	if err := r.applyTags(ctx, scope, data.Mode.ValueString(), settings, tagsAll, nil); err != nil {
+		resp.Diagnostics.AddError("Error setting resource group tags", err.Error())
+		return
+	}
+
+	tagsAllValue, diags := types.MapValueFrom(ctx, types.StringType, tagsAll)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.TagsAll = tagsAllValue
+	data.ID = types.StringValue(scope)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ResourceGroupTagsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *ResourceGroupTagsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stateTags, diags := tagsMap(ctx, data.Tags)
+	resp.Diagnostics.Append(diags...)
+	stateTagsAll, diags := tagsMap(ctx, data.TagsAll)
+	resp.Diagnostics.Append(diags...)
+	filter, diags := data.tagFilter(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	scope := data.scope()
+	remote, err := readTags(ctx, r.TagsClient, scope)
+	if subscriptionSettings.IsNotFound(err) {
+		tflog.Debug(ctx, fmt.Sprintf("resource group %s no longer exists, removing from state", scope))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading resource group tags", fmt.Sprintf("resource group %s: %s", scope, err.Error()))
+		return
+	}
+
+	tags, tagsAll := r.TagsSettings.withIgnore(filter).fromRemote(remote, data.Mode.ValueString(), stateTags, stateTagsAll)
+
+	tagsValue, diags := types.MapValueFrom(ctx, types.StringType, tags)
+	resp.Diagnostics.Append(diags...)
+	tagsAllValue, diags := types.MapValueFrom(ctx, types.StringType, tagsAll)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Tags = tagsValue
+	data.TagsAll = tagsAllValue
+	data.ID = types.StringValue(scope)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ResourceGroupTagsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *ResourceGroupTagsResourceModel
+	var oldData *ResourceGroupTagsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &oldData)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updating resource group tags resource")
+
+	tfTags := make(map[string]string)
+	resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tfTags, false)...)
+	previous, diags := lastAppliedTags(ctx, oldData.Tags, oldData.TagsAll)
+	resp.Diagnostics.Append(diags...)
+	filter, diags := data.tagFilter(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings := r.TagsSettings.withIgnore(filter)
+	tagsAll := settings.all(tfTags)
+	if err := r.applyTags(ctx, data.scope(), data.Mode.ValueString(), settings, tagsAll, previous); err != nil {
+		resp.Diagnostics.AddError("Error updating resource group tags", err.Error())
+		return
+	}
+
+	tagsAllValue, diags := types.MapValueFrom(ctx, types.StringType, tagsAll)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.TagsAll = tagsAllValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ResourceGroupTagsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *ResourceGroupTagsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "deleting resource group tags resource")
+
+	// In merge mode only the managed keys are removed
+	previous, diags := lastAppliedTags(ctx, data.Tags, data.TagsAll)
+	resp.Diagnostics.Append(diags...)
+	filter, diags := data.tagFilter(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.applyTags(ctx, data.scope(), data.Mode.ValueString(), r.TagsSettings.withIgnore(filter), map[string]string{}, previous)
+	if subscriptionSettings.IsNotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error removing resource group tags", err.Error())
+		return
+	}
+}
+
+// ImportState accepts the resource group ID, e.g.
+// /subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example.
+// Imported tags are managed authoritatively.
+func (r *ResourceGroupTagsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	match := resourceGroupIDPattern.FindStringSubmatch(req.ID)
+	if match == nil {
+		resp.Diagnostics.AddError("Invalid import ID",
+			fmt.Sprintf("Expected /subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}, got %q.", req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("subscription_id"), match[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resource_group_name"), match[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("mode"), tagsModeAuthoritative)...)
+}
+
+// applyTags writes tags to the resource group. In authoritative mode the
+// ignored tags currently set are carried over so they survive the replace.
+func (r *ResourceGroupTagsResource) applyTags(ctx context.Context, scope string, mode string, settings tagsSettings, tags map[string]string, previous map[string]string) error {
+	if err := applyTagsAll(ctx, r.TagsClient, scope, mode, settings, tags, previous); err != nil {
+		return fmt.Errorf("failed to set tags for resource group %q: %w", scope, err)
+	}
+	return nil
+}
+
+func (m *ResourceGroupTagsResourceModel) scope() string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s", m.SubscriptionID.ValueString(), m.ResourceGroupName.ValueString())
+}
+
+func (m *ResourceGroupTagsResourceModel) tagFilter(ctx context.Context) (tagFilter, diag.Diagnostics) {
+	var filter tagFilter
+	var diags diag.Diagnostics
+
+	if !m.IgnoreTagKeys.IsNull() && !m.IgnoreTagKeys.IsUnknown() {
+		diags.Append(m.IgnoreTagKeys.ElementsAs(ctx, &filter.keys, false)...)
+	}
+	if !m.IgnoreTagPrefixes.IsNull() && !m.IgnoreTagPrefixes.IsUnknown() {
+		diags.Append(m.IgnoreTagPrefixes.ElementsAs(ctx, &filter.prefixes, false)...)
+	}
+	return filter, diags
+}
@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"maps"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccResourceGroupTagsResource(t *testing.T) {
+	skipWithoutTerraform(t)
+	fake := newFakeARM(t)
+	scope := fakeSubscriptionScope + "/resourceGroups/example"
+	fake.setTags(scope, map[string]string{"Environment": "dev", "inherited-cost-center": "1234", "Policy": "enforced"})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fake.providerFactories(),
+		// Ignored tags survive the destroy of an authoritative resource.
+		CheckDestroy: fake.checkTags(scope, map[string]string{"inherited-cost-center": "1234", "Policy": "enforced"}),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + testAccResourceGroupTagsResourceConfig(`Environment = "test"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("azurex_resource_group_tags.test", "id", scope),
+					resource.TestCheckResourceAttr("azurex_resource_group_tags.test", "subscription_id", fakeSubscriptionID),
+					resource.TestCheckResourceAttr("azurex_resource_group_tags.test", "tags.%", "1"),
+					fake.checkTags(scope, map[string]string{"Environment": "test", "inherited-cost-center": "1234", "Policy": "enforced"}),
+				),
+			},
+			{
+				// Inherited and policy tags changing is not drift.
+				PreConfig: func() {
+					fake.setTags(scope, map[string]string{"Environment": "test", "inherited-cost-center": "5678", "inherited-owner": "x", "policy": "audit"})
+				},
+				Config: fake.providerConfig() + testAccResourceGroupTagsResourceConfig(`Environment = "test"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				// Other tags are still drift, and the fix keeps the ignored ones.
+				PreConfig: func() {
+					tags := fake.getTagsAt(scope)
+					tags["Rogue"] = "true"
+					fake.setTags(scope, tags)
+				},
+				Config: fake.providerConfig() + testAccResourceGroupTagsResourceConfig(`Environment = "test"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("azurex_resource_group_tags.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: fake.checkTags(scope, map[string]string{"Environment": "test", "inherited-cost-center": "5678", "inherited-owner": "x", "policy": "audit"}),
+			},
+			{
+				ResourceName:            "azurex_resource_group_tags.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"ignore_tag_keys", "ignore_tag_prefixes", "tags", "tags_all"},
+			},
+			{
+				PreConfig: func() {
+					fake.setTags(scope, map[string]string{"Environment": "test", "inherited-cost-center": "1234", "Policy": "enforced"})
+				},
+				Config: fake.providerConfig() + testAccResourceGroupTagsResourceConfig(`Environment = "test"`),
+			},
+		},
+	})
+}
+
+func TestTagFilter(t *testing.T) {
+	filter := tagFilter{keys: []string{"Policy"}, prefixes: []string{"inherited-"}}
+
+	tags := map[string]string{"Environment": "test", "policy": "audit", "Inherited-Owner": "x", "Policy": "keep"}
+	if got, want := filter.filter(tags, map[string]string{"Policy": "keep"}), map[string]string{"Environment": "test", "Policy": "keep"}; !maps.Equal(got, want) {
+		t.Fatalf("expected filtered tags %v, got %v", want, got)
+	}
+
+	current := map[string]string{"Environment": "prod", "inherited-cost-center": "1234", "Rogue": "true"}
+	if got, want := filter.preserve(map[string]string{"Environment": "test"}, current), map[string]string{"Environment": "test", "inherited-cost-center": "1234"}; !maps.Equal(got, want) {
+		t.Fatalf("expected preserved tags %v, got %v", want, got)
+	}
+}
+
+func testAccResourceGroupTagsResourceConfig(tags string) string {
+	return fmt.Sprintf(`
+resource "azurex_resource_group_tags" "test" {
+  resource_group_name = "example"
+  ignore_tag_keys     = ["Policy"]
+  ignore_tag_prefixes = ["inherited-"]
+
+  tags = {
+    %s
+  }
+}
+`, tags)
+}
@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SubscriptionTagsDataSource{}
+
+func NewSubscriptionTagsDataSource() datasource.DataSource {
+	return &SubscriptionTagsDataSource{}
+}
+
+// SubscriptionTagsDataSource defines the data source implementation.
+type SubscriptionTagsDataSource struct {
+	TagsClient     *armresources.TagsClient
+	SubscriptionID string
+}
+
+// SubscriptionTagsDataSourceModel describes the data source data model.
+type SubscriptionTagsDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	SubscriptionID types.String `tfsdk:"subscription_id"`
+	Tags           types.Map    `tfsdk:"tags"`
+}
+
+func (d *SubscriptionTagsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_subscription_tags"
+}
+
+func (d *SubscriptionTagsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the tags of a subscription without managing them",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Resource ID of the subscription",
+				Computed:            true,
+			},
+			"subscription_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the subscription to read, defaults to the provider subscription",
+				Optional:            true,
+				Computed:            true,
+			},
+			"tags": schema.MapAttribute{
+				MarkdownDescription: "Tags set on the subscription",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *SubscriptionTagsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(AzurexContext)
+	if !ok {
+		resp.Diagnostics.AddError("unable to obtain provider data", "provider data not available")
+		return
+	}
+
+	tagsClient, err := armresources.NewTagsClient(data.SubscriptionID, data.IdentityCreds, data.ClientOptions)
+	if err != nil {
+		resp.Diagnostics.AddError("unable to configure tags client", fmt.Sprintf("got: %s", err.Error()))
+		return
+	}
+	d.TagsClient = tagsClient
+
+	d.SubscriptionID = data.SubscriptionID
+}
+
+func (d *SubscriptionTagsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SubscriptionTagsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.SubscriptionID.IsNull() || data.SubscriptionID.IsUnknown() {
+		data.SubscriptionID = types.StringValue(d.SubscriptionID)
+	}
+	subscriptionID := data.SubscriptionID.ValueString()
+	if subscriptionID == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("subscription_id"), "Missing subscription ID",
+			"The subscription_id attribute must be set when the provider has no subscription_id configured.")
+		return
+	}
+
+	scope := fmt.Sprintf("/subscriptions/%s", subscriptionID)
+
+	tflog.Trace(ctx, fmt.Sprintf("reading tags of %s", scope))
+
+	tags, err := readTags(ctx, d.TagsClient, scope)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading subscription tags", err.Error())
+		return
+	}
+
+	tagsValue, diags := types.MapValueFrom(ctx, types.StringType, tags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(scope)
+	data.Tags = tagsValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
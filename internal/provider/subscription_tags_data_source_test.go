@@ -0,0 +1,33 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSubscriptionTagsDataSource(t *testing.T) {
+	skipWithoutTerraform(t)
+	fake := newFakeARM(t)
+	fake.setTags(fakeSubscriptionScope, map[string]string{"CostCenter": "1234", "Owner": "platform"})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fake.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+data "azurex_subscription_tags" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.azurex_subscription_tags.test", "id", fakeSubscriptionScope),
+					resource.TestCheckResourceAttr("data.azurex_subscription_tags.test", "subscription_id", fakeSubscriptionID),
+					resource.TestCheckResourceAttr("data.azurex_subscription_tags.test", "tags.%", "2"),
+					resource.TestCheckResourceAttr("data.azurex_subscription_tags.test", "tags.CostCenter", "1234"),
+				),
+			},
+		},
+	})
+}
@@ -6,13 +6,19 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armsubscriptions"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
@@ -22,6 +28,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &SubscriptionTagsResource{}
 var _ resource.ResourceWithImportState = &SubscriptionTagsResource{}
+var _ resource.ResourceWithModifyPlan = &SubscriptionTagsResource{}
 
 func NewSubscriptionTagsResource() resource.Resource {
 	return &SubscriptionTagsResource{}
@@ -33,15 +40,19 @@ type SubscriptionTagsResource struct {
 	SubscriptionsClient *armsubscriptions.SubscriptionClient
 	TagsClient          *armresources.TagsClient
 	SubscriptionID      string
+	TagsSettings        tagsSettings
 }
 
 // SubscriptionTagsResourceModel describes the resource data model.
 type SubscriptionTagsResourceModel struct {
-	Tags              types.Map  `tfsdk:"tags"`
-	InheritTags       types.Bool `tfsdk:"inherit_tags"`
-	PreferContainers  types.Bool `tfsdk:"prefer_containers"`
-	RemoveTags        types.Bool `tfsdk:"ondelete_remove_tags"`
-	RemoteInheritTags types.Bool `tfsdk:"ondelete_remove_inherit_tags"`
+	SubscriptionID    types.String `tfsdk:"subscription_id"`
+	Tags              types.Map    `tfsdk:"tags"`
+	TagsAll           types.Map    `tfsdk:"tags_all"`
+	Mode              types.String `tfsdk:"mode"`
+	InheritTags       types.Bool   `tfsdk:"inherit_tags"`
+	PreferContainers  types.Bool   `tfsdk:"prefer_containers"`
+	RemoveTags        types.Bool   `tfsdk:"ondelete_remove_tags"`
+	RemoteInheritTags types.Bool   `tfsdk:"ondelete_remove_inherit_tags"`
 }
 
 func (r *SubscriptionTagsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -53,18 +64,46 @@ func (r *SubscriptionTagsResource) Schema(ctx context.Context, req resource.Sche
 		MarkdownDescription: "Subscription Tags",
 
 		Attributes: map[string]schema.Attribute{
+			"subscription_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the subscription to tag, defaults to the provider subscription. Changing this forces a new resource to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 			"tags": schema.MapAttribute{
 				Required:            true,
 				ElementType:         types.StringType,
 				MarkdownDescription: "Tags to apply to a subscription",
 			},
-			"inherit_tags": schema.BoolAttribute{
-				MarkdownDescription: "Enables Inherit Tags (does not disable inherit tags on destroy)",
+			"tags_all": schema.MapAttribute{
+				MarkdownDescription: "Tags applied to the subscription, including the provider `default_tags`",
+				ElementType:         types.StringType,
 				Computed:            true,
-				Default:             booldefault.StaticBool(true),
+			},
+			"mode": schema.StringAttribute{
+				MarkdownDescription: "How tags are applied, either `authoritative` to replace every tag on the subscription or `merge` to only manage the keys set in `tags`. Defaults to `authoritative`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(tagsModeAuthoritative),
+				Validators: []validator.String{
+					stringvalidator.OneOf(tagsModes...),
+				},
+			},
+			"inherit_tags": schema.BoolAttribute{
+				MarkdownDescription: "Enables Inherit Tags (does not disable inherit tags on destroy). Defaults to `true`, so this resource manages the tag inheritance setting of the subscription unless set to `false`. " +
+					"Set it to `false` when `azurex_cost_tag_inheritance` manages the same subscription, otherwise both resources write the setting and keep undoing each other, e.g. `prefer_containers` defaults to `true` while `prefer_container_tags` defaults to `false`.",
+				DeprecationMessage: "Use the azurex_cost_tag_inheritance resource instead and set inherit_tags = false.",
+				Optional:           true,
+				Computed:           true,
+				Default:            booldefault.StaticBool(true),
 			},
 			"prefer_containers": schema.BoolAttribute{
-				MarkdownDescription: "Prefer subscription/resource group tags over resource tags when there's a conflict",
+				MarkdownDescription: "Prefer subscription/resource group tags over resource tags when there's a conflict. Defaults to `true`, unlike `prefer_container_tags` on `azurex_cost_tag_inheritance`.",
+				DeprecationMessage:  "Use prefer_container_tags on the azurex_cost_tag_inheritance resource instead.",
+				Optional:            true,
 				Computed:            true,
 				Default:             booldefault.StaticBool(true),
 			},
@@ -93,21 +132,21 @@ func (r *SubscriptionTagsResource) Configure(ctx context.Context, req resource.C
 		return
 	}
 
-	settingsClient, err := subscriptionSettings.NewSettingsClient(data.SubscriptionID, data.IdentityCreds, nil)
+	settingsClient, err := subscriptionSettings.NewSettingsClient(data.SubscriptionID, data.IdentityCreds, data.ClientOptions)
 	if err != nil {
 		resp.Diagnostics.AddError("unable to configure settings client", fmt.Sprintf("got: %s", err.Error()))
 		return
 	}
 	r.SettingsClient = settingsClient
 
-	subClient, err := armsubscriptions.NewSubscriptionClient(data.IdentityCreds, nil)
+	subClient, err := armsubscriptions.NewSubscriptionClient(data.IdentityCreds, data.ClientOptions)
 	if err != nil {
 		resp.Diagnostics.AddError("unable to configure subscription client", fmt.Sprintf("got: %s", err.Error()))
 		return
 	}
 	r.SubscriptionsClient = subClient
 
-	tagsClient, err := armresources.NewTagsClient(data.SubscriptionID, data.IdentityCreds, nil)
+	tagsClient, err := armresources.NewTagsClient(data.SubscriptionID, data.IdentityCreds, data.ClientOptions)
 	if err != nil {
 		resp.Diagnostics.AddError("unable to configure tags client", fmt.Sprintf("got: %s", err.Error()))
 		return
@@ -115,6 +154,16 @@ func (r *SubscriptionTagsResource) Configure(ctx context.Context, req resource.C
 	r.TagsClient = tagsClient
 
 	r.SubscriptionID = data.SubscriptionID
+	r.TagsSettings = data.TagsSettings
+}
+
+func (r *SubscriptionTagsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(planTagsAll(ctx, r.TagsSettings, &resp.Plan)...)
 }
 
 func (r *SubscriptionTagsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -128,22 +177,40 @@ func (r *SubscriptionTagsResource) Create(ctx context.Context, req resource.Crea
 
 	tflog.Trace(ctx, "creating subscription tags resource")
 
+	if data.SubscriptionID.IsUnknown() || data.SubscriptionID.IsNull() {
+		data.SubscriptionID = types.StringValue(r.SubscriptionID)
+	}
+	subscriptionID := data.SubscriptionID.ValueString()
+	if subscriptionID == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("subscription_id"), "Missing subscription ID",
+			"The subscription_id attribute must be set when the provider has no subscription_id configured.")
+		return
+	}
+
 	tfTags := make(map[string]string)
 	resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tfTags, false)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	err := r.applyTags(ctx, r.SubscriptionID, tfTags)
+	tagsAll := r.TagsSettings.all(tfTags)
+	err := r.applyTags(ctx, subscriptionID, data.Mode.ValueString(), tagsAll, nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Error applying tags to subscription", err.Error())
 		return
 	}
 
+	tagsAllValue, diags := types.MapValueFrom(ctx, types.StringType, tagsAll)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.TagsAll = tagsAllValue
+
 	if data.InheritTags.ValueBool() {
-		tagInheritance, err := r.SettingsClient.EnableTagInheritance(ctx, data.PreferContainers.ValueBool())
+		tagInheritance, err := r.SettingsClient.WithSubscription(subscriptionID).EnableTagInheritance(ctx, data.PreferContainers.ValueBool())
 		if err != nil {
-			resp.Diagnostics.AddError("Error configuring tag inheritance", err.Error())
+			resp.Diagnostics.Append(responseErrorDiagnostic("Error configuring tag inheritance", err))
 			return
 		}
 
@@ -165,43 +232,53 @@ func (r *SubscriptionTagsResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
-	scope := fmt.Sprintf("/subscriptions/%s", r.SubscriptionID)
+	subscriptionID := data.SubscriptionID.ValueString()
+	scope := fmt.Sprintf("/subscriptions/%s", subscriptionID)
+
+	stateTags, diags := tagsMap(ctx, data.Tags)
+	resp.Diagnostics.Append(diags...)
+	stateTagsAll, diags := tagsMap(ctx, data.TagsAll)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Get tags using TagsClient instead of SubscriptionClient
-	tagsResponse, err := r.TagsClient.GetAtScope(ctx, scope, nil)
+	remote, err := readTags(ctx, r.TagsClient, scope)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading subscription tags", fmt.Sprintf("Unable to read tags for subscription %s: %s", r.SubscriptionID, err))
+		resp.Diagnostics.AddError("Error reading subscription tags", fmt.Sprintf("Unable to read tags for subscription %s: %s", subscriptionID, err))
 		return
 	}
 
-	// Convert Azure tags to Terraform tags
-	tfTags := make(map[string]string)
-	if tagsResponse.Properties != nil && tagsResponse.Properties.Tags != nil {
-		for k, v := range tagsResponse.Properties.Tags {
-			if v != nil {
-				tfTags[k] = *v
-			}
-		}
-	}
+	// In merge mode only the keys Terraform manages are reported
+	tfTags, tagsAll := r.TagsSettings.fromRemote(remote, data.Mode.ValueString(), stateTags, stateTagsAll)
 
 	tagsValue, diags := types.MapValueFrom(ctx, types.StringType, tfTags)
 	resp.Diagnostics.Append(diags...)
+	tagsAllValue, diags := types.MapValueFrom(ctx, types.StringType, tagsAll)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	data.Tags = tagsValue
+	data.TagsAll = tagsAllValue
+
+	// With inherit_tags = false the setting belongs to azurex_cost_tag_inheritance
+	// (or nobody), so it is not read back and never shows up as drift here. It
+	// is null right after an import.
+	if data.InheritTags.IsNull() || data.InheritTags.ValueBool() {
+		tagInheritance, err := r.SettingsClient.WithSubscription(subscriptionID).GetTagInheritance(ctx)
+		if err != nil && !subscriptionSettings.IsNotFound(err) {
+			resp.Diagnostics.Append(responseErrorDiagnostic("Error getting tag inheritance settings", err))
+			return
+		}
 
-	tagInheritance, err := r.SettingsClient.GetTagInheritance(ctx)
-	if err != nil {
-		resp.Diagnostics.AddError("Error getting tag inheritance settings", err.Error())
-		return
-	}
-
-	if tagInheritance.Id != "" {
-		data.InheritTags = types.BoolValue(true)
-		data.PreferContainers = types.BoolValue(tagInheritance.Properties.PreferContainerTags)
-	} else {
-		data.InheritTags = types.BoolValue(false)
+		if err == nil && tagInheritance.Id != "" {
+			data.InheritTags = types.BoolValue(true)
+			data.PreferContainers = types.BoolValue(tagInheritance.Properties.PreferContainerTags)
+		} else {
+			data.InheritTags = types.BoolValue(false)
+		}
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -220,22 +297,35 @@ func (r *SubscriptionTagsResource) Update(ctx context.Context, req resource.Upda
 
 	tflog.Trace(ctx, "updating subscription tags resource")
 
+	subscriptionID := data.SubscriptionID.ValueString()
+	settingsClient := r.SettingsClient.WithSubscription(subscriptionID)
+
 	tfTags := make(map[string]string)
 	resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tfTags, false)...)
+	previous, diags := lastAppliedTags(ctx, oldData.Tags, oldData.TagsAll)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	err := r.applyTags(ctx, r.SubscriptionID, tfTags)
+	tagsAll := r.TagsSettings.all(tfTags)
+	err := r.applyTags(ctx, subscriptionID, data.Mode.ValueString(), tagsAll, previous)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating subscription tags", err.Error())
 		return
 	}
 
+	tagsAllValue, diags := types.MapValueFrom(ctx, types.StringType, tagsAll)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.TagsAll = tagsAllValue
+
 	if data.InheritTags.ValueBool() {
-		tagInheritance, err := r.SettingsClient.EnableTagInheritance(ctx, data.PreferContainers.ValueBool())
+		tagInheritance, err := settingsClient.EnableTagInheritance(ctx, data.PreferContainers.ValueBool())
 		if err != nil {
-			resp.Diagnostics.AddError("Error updating tag inheritance settings", err.Error())
+			resp.Diagnostics.Append(responseErrorDiagnostic("Error updating tag inheritance settings", err))
 			return
 		}
 
@@ -244,9 +334,9 @@ func (r *SubscriptionTagsResource) Update(ctx context.Context, req resource.Upda
 			data.PreferContainers = types.BoolValue(tagInheritance.Properties.PreferContainerTags)
 		}
 	} else if oldData.InheritTags.ValueBool() && !data.InheritTags.ValueBool() {
-		_, err := r.SettingsClient.DisableTagInheritance(ctx)
+		err := disableTagInheritance(ctx, settingsClient)
 		if err != nil {
-			resp.Diagnostics.AddError("Error disabling tag inheritance", err.Error())
+			resp.Diagnostics.Append(responseErrorDiagnostic("Error disabling tag inheritance", err))
 			return
 		}
 		data.InheritTags = types.BoolValue(false)
@@ -266,8 +356,17 @@ func (r *SubscriptionTagsResource) Delete(ctx context.Context, req resource.Dele
 
 	tflog.Trace(ctx, "deleting subscription tags resource")
 
+	subscriptionID := data.SubscriptionID.ValueString()
+
 	if data.RemoveTags.ValueBool() {
-		err := r.applyTags(ctx, r.SubscriptionID, map[string]string{})
+		// In merge mode only the managed keys are removed
+		previous, diags := lastAppliedTags(ctx, data.Tags, data.TagsAll)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		err := r.applyTags(ctx, subscriptionID, data.Mode.ValueString(), map[string]string{}, previous)
 		if err != nil {
 			resp.Diagnostics.AddError("Error removing subscription tags", err.Error())
 			return
@@ -275,33 +374,34 @@ func (r *SubscriptionTagsResource) Delete(ctx context.Context, req resource.Dele
 	}
 
 	if data.RemoteInheritTags.ValueBool() && data.InheritTags.ValueBool() {
-		_, err := r.SettingsClient.DisableTagInheritance(ctx)
+		err := disableTagInheritance(ctx, r.SettingsClient.WithSubscription(subscriptionID))
 		if err != nil {
-			resp.Diagnostics.AddError("Error disabling tag inheritance", err.Error())
+			resp.Diagnostics.Append(responseErrorDiagnostic("Error disabling tag inheritance", err))
 			return
 		}
 	}
 }
 
+// ImportState accepts either a bare subscription ID or its resource ID,
+// e.g. /subscriptions/00000000-0000-0000-0000-000000000000.
 func (r *SubscriptionTagsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("subscription_id"), req, resp)
-}
-
-func (r *SubscriptionTagsResource) applyTags(ctx context.Context, subscriptionID string, tagMap map[string]string) error {
-	azureTags := make(map[string]*string)
-	for k, v := range tagMap {
-		value := v
-		azureTags[k] = &value
+	subscriptionID := strings.TrimPrefix(strings.TrimSuffix(req.ID, "/"), "/subscriptions/")
+	if subscriptionID == "" || strings.Contains(subscriptionID, "/") {
+		resp.Diagnostics.AddError("Invalid import ID",
+			fmt.Sprintf("Expected a subscription ID or /subscriptions/{subscriptionId}, got %q.", req.ID))
+		return
 	}
 
-	scope := fmt.Sprintf("/subscriptions/%s", subscriptionID)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("subscription_id"), subscriptionID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("mode"), tagsModeAuthoritative)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("ondelete_remove_tags"), true)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("ondelete_remove_inherit_tags"), false)...)
+}
 
-	_, err := r.TagsClient.CreateOrUpdateAtScope(ctx, scope, armresources.TagsResource{
-		Properties: &armresources.Tags{
-			Tags: azureTags,
-		},
-	}, nil)
+func (r *SubscriptionTagsResource) applyTags(ctx context.Context, subscriptionID string, mode string, tagMap map[string]string, previous map[string]string) error {
+	scope := fmt.Sprintf("/subscriptions/%s", subscriptionID)
 
+	err := applyTagsAll(ctx, r.TagsClient, scope, mode, r.TagsSettings, tagMap, previous)
 	if err != nil {
 		return fmt.Errorf("failed to set tags for subscription %q: %+v", subscriptionID, err)
 	}
@@ -0,0 +1,475 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+const fakeSubscriptionScope = "/subscriptions/" + fakeSubscriptionID
+
+func TestAccSubscriptionTagsResource(t *testing.T) {
+	skipWithoutTerraform(t)
+	fake := newFakeARM(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fake.providerFactories(),
+		CheckDestroy: func(*terraform.State) error {
+			if tags := fake.getTagsAt(fakeSubscriptionScope); len(tags) != 0 {
+				return fmt.Errorf("expected the subscription tags to be removed, got %v", tags)
+			}
+			if enabled, _ := fake.tagInheritanceAt(fakeSubscriptionScope); !enabled {
+				return fmt.Errorf("expected tag inheritance to stay enabled")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + testAccSubscriptionTagsResourceConfig(`Environment = "test"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("azurex_subscription_tags.test", "subscription_id", fakeSubscriptionID),
+					resource.TestCheckResourceAttr("azurex_subscription_tags.test", "mode", tagsModeAuthoritative),
+					resource.TestCheckResourceAttr("azurex_subscription_tags.test", "tags.%", "1"),
+					resource.TestCheckResourceAttr("azurex_subscription_tags.test", "tags.Environment", "test"),
+					resource.TestCheckResourceAttr("azurex_subscription_tags.test", "inherit_tags", "true"),
+					fake.checkTags(fakeSubscriptionScope, map[string]string{"Environment": "test"}),
+				),
+			},
+			{
+				Config: fake.providerConfig() + testAccSubscriptionTagsResourceConfig(`Environment = "prod"
+    Owner       = "platform"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("azurex_subscription_tags.test", "tags.%", "2"),
+					resource.TestCheckResourceAttr("azurex_subscription_tags.test", "tags.Owner", "platform"),
+					fake.checkTags(fakeSubscriptionScope, map[string]string{"Environment": "prod", "Owner": "platform"}),
+				),
+			},
+			{
+				ResourceName:                         "azurex_subscription_tags.test",
+				ImportState:                          true,
+				ImportStateId:                        fakeSubscriptionScope,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "subscription_id",
+			},
+			{
+				// Drift, a tag is changed and another added outside of Terraform.
+				PreConfig: func() {
+					fake.setTags(fakeSubscriptionScope, map[string]string{"Environment": "dev", "Owner": "platform", "Rogue": "true"})
+				},
+				Config: fake.providerConfig() + testAccSubscriptionTagsResourceConfig(`Environment = "prod"
+    Owner       = "platform"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("azurex_subscription_tags.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: fake.checkTags(fakeSubscriptionScope, map[string]string{"Environment": "prod", "Owner": "platform"}),
+			},
+		},
+	})
+}
+
+func TestAccSubscriptionTagsResource_merge(t *testing.T) {
+	skipWithoutTerraform(t)
+	fake := newFakeARM(t)
+	fake.setTags(fakeSubscriptionScope, map[string]string{"Owner": "policy"})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fake.providerFactories(),
+		CheckDestroy:             fake.checkTags(fakeSubscriptionScope, map[string]string{"Owner": "policy"}),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + testAccSubscriptionTagsResourceMergeConfig(`CostCenter = "1234"
+    Team       = "finops"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("azurex_subscription_tags.test", "tags.%", "2"),
+					fake.checkTags(fakeSubscriptionScope, map[string]string{"Owner": "policy", "CostCenter": "1234", "Team": "finops"}),
+				),
+			},
+			{
+				Config: fake.providerConfig() + testAccSubscriptionTagsResourceMergeConfig(`CostCenter = "5678"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("azurex_subscription_tags.test", "tags.%", "1"),
+					fake.checkTags(fakeSubscriptionScope, map[string]string{"Owner": "policy", "CostCenter": "5678"}),
+				),
+			},
+			{
+				// Tags set outside of Terraform are not drift in merge mode.
+				PreConfig: func() {
+					fake.setTags(fakeSubscriptionScope, map[string]string{"Owner": "policy", "CostCenter": "5678", "Extra": "x"})
+				},
+				Config: fake.providerConfig() + testAccSubscriptionTagsResourceMergeConfig(`CostCenter = "5678"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				PreConfig: func() {
+					fake.setTags(fakeSubscriptionScope, map[string]string{"Owner": "policy", "CostCenter": "5678"})
+				},
+				Config: fake.providerConfig() + testAccSubscriptionTagsResourceMergeConfig(`CostCenter = "5678"`),
+			},
+		},
+	})
+}
+
+func TestAccSubscriptionTagsResource_disableInheritTags(t *testing.T) {
+	skipWithoutTerraform(t)
+	fake := newFakeARM(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fake.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + testAccSubscriptionTagsResourceConfig(`Environment = "test"`),
+				Check:  fake.checkTagInheritance(fakeSubscriptionScope, true, true),
+			},
+			{
+				Config: fake.providerConfig() + `
+resource "azurex_subscription_tags" "test" {
+  inherit_tags = false
+
+  tags = {
+    Environment = "test"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("azurex_subscription_tags.test", "inherit_tags", "false"),
+					fake.checkTagInheritance(fakeSubscriptionScope, false, false),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSubscriptionTagsResource_defaultTags(t *testing.T) {
+	skipWithoutTerraform(t)
+	fake := newFakeARM(t)
+	fake.setTags(fakeSubscriptionScope, map[string]string{"hidden-policy": "audit"})
+
+	providerConfig := fake.providerConfigWith(`
+  default_tags = {
+    CostCenter = "1234"
+    Owner      = "platform"
+  }
+
+  ignore_tags = {
+    key_prefixes = ["hidden-"]
+  }
+`)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fake.providerFactories(),
+		// Ignored tags survive the destroy of an authoritative resource.
+		CheckDestroy: fake.checkTags(fakeSubscriptionScope, map[string]string{"hidden-policy": "deny"}),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccSubscriptionTagsResourceConfig(`Environment = "test"
+    Owner       = "finops"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("azurex_subscription_tags.test", "tags.%", "2"),
+					resource.TestCheckResourceAttr("azurex_subscription_tags.test", "tags_all.%", "3"),
+					resource.TestCheckResourceAttr("azurex_subscription_tags.test", "tags_all.CostCenter", "1234"),
+					resource.TestCheckResourceAttr("azurex_subscription_tags.test", "tags_all.Owner", "finops"),
+					fake.checkTags(fakeSubscriptionScope, map[string]string{"Environment": "test", "Owner": "finops", "CostCenter": "1234", "hidden-policy": "audit"}),
+				),
+			},
+			{
+				ResourceName:                         "azurex_subscription_tags.test",
+				ImportState:                          true,
+				ImportStateId:                        fakeSubscriptionScope,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "subscription_id",
+			},
+			{
+				// Ignored tags changing is not drift.
+				PreConfig: func() {
+					fake.setTags(fakeSubscriptionScope, map[string]string{"Environment": "test", "Owner": "finops", "CostCenter": "1234", "hidden-policy": "deny"})
+				},
+				Config: providerConfig + testAccSubscriptionTagsResourceConfig(`Environment = "test"
+    Owner       = "finops"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				// A default tag removed outside of Terraform is restored.
+				PreConfig: func() {
+					fake.setTags(fakeSubscriptionScope, map[string]string{"Environment": "test", "Owner": "finops", "hidden-policy": "deny"})
+				},
+				Config: providerConfig + testAccSubscriptionTagsResourceConfig(`Environment = "test"
+    Owner       = "finops"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("azurex_subscription_tags.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue("azurex_subscription_tags.test", tfjsonpath.New("tags_all").AtMapKey("CostCenter"), knownvalue.StringExact("1234")),
+					},
+				},
+				Check: fake.checkTags(fakeSubscriptionScope, map[string]string{"Environment": "test", "Owner": "finops", "CostCenter": "1234", "hidden-policy": "deny"}),
+			},
+		},
+	})
+}
+
+func TestSubscriptionTagsResource_subscriptionID(t *testing.T) {
+	const otherSubscriptionID = "00000000-0000-0000-0000-000000000001"
+
+	cases := map[string]struct {
+		providerSubscriptionID string
+		subscriptionID         types.String
+		want                   string
+	}{
+		"provider subscription": {
+			providerSubscriptionID: fakeSubscriptionID,
+			subscriptionID:         types.StringUnknown(),
+			want:                   fakeSubscriptionID,
+		},
+		"resource subscription": {
+			providerSubscriptionID: fakeSubscriptionID,
+			subscriptionID:         types.StringValue(otherSubscriptionID),
+			want:                   otherSubscriptionID,
+		},
+		"resource subscription without provider subscription": {
+			subscriptionID: types.StringValue(otherSubscriptionID),
+			want:           otherSubscriptionID,
+		},
+		"no subscription": {
+			subscriptionID: types.StringUnknown(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := newFakeARM(t)
+			fake.addSubscription(otherSubscriptionID)
+
+			r := &SubscriptionTagsResource{}
+			var configureResp fwresource.ConfigureResponse
+			r.Configure(ctx, fwresource.ConfigureRequest{ProviderData: fake.providerData(tc.providerSubscriptionID)}, &configureResp)
+			if configureResp.Diagnostics.HasError() {
+				t.Fatalf("configuring resource: %v", configureResp.Diagnostics)
+			}
+
+			var schemaResp fwresource.SchemaResponse
+			r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+			tags, _ := types.MapValueFrom(ctx, types.StringType, map[string]string{"Environment": "test"})
+			if diags := plan.Set(ctx, &SubscriptionTagsResourceModel{
+				SubscriptionID:    tc.subscriptionID,
+				Tags:              tags,
+				TagsAll:           types.MapUnknown(types.StringType),
+				Mode:              types.StringValue(tagsModeAuthoritative),
+				InheritTags:       types.BoolValue(true),
+				PreferContainers:  types.BoolValue(true),
+				RemoveTags:        types.BoolValue(true),
+				RemoteInheritTags: types.BoolValue(false),
+			}); diags.HasError() {
+				t.Fatalf("building plan: %v", diags)
+			}
+
+			resp := fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+			r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &resp)
+
+			if tc.want == "" {
+				if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != "Missing subscription ID" {
+					t.Fatalf("expected a missing subscription ID error, got %v", resp.Diagnostics)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("creating resource: %v", resp.Diagnostics)
+			}
+
+			var got SubscriptionTagsResourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &got)...)
+			if got.SubscriptionID.ValueString() != tc.want {
+				t.Fatalf("expected subscription_id %q in state, got %q", tc.want, got.SubscriptionID.ValueString())
+			}
+			for _, subscriptionID := range []string{fakeSubscriptionID, otherSubscriptionID} {
+				scope := "/subscriptions/" + subscriptionID
+				wantTags := map[string]string{}
+				if subscriptionID == tc.want {
+					wantTags["Environment"] = "test"
+				}
+				if err := fake.checkTags(scope, wantTags)(nil); err != nil {
+					t.Fatal(err)
+				}
+				if err := fake.checkTagInheritance(scope, subscriptionID == tc.want, subscriptionID == tc.want)(nil); err != nil {
+					t.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestSubscriptionTagsResource_importState(t *testing.T) {
+	ctx := context.Background()
+	r := &SubscriptionTagsResource{}
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	for id, want := range map[string]string{
+		fakeSubscriptionID:                                fakeSubscriptionID,
+		"/subscriptions/" + fakeSubscriptionID:            fakeSubscriptionID,
+		"/subscriptions/" + fakeSubscriptionID + "/":      fakeSubscriptionID,
+		"/subscriptions/":                                 "",
+		fakeSubscriptionScope + "/resourceGroups/example": "",
+	} {
+		resp := fwresource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		resp.State.Raw = tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)
+		r.ImportState(ctx, fwresource.ImportStateRequest{ID: id}, &resp)
+
+		if want == "" {
+			if !resp.Diagnostics.HasError() {
+				t.Errorf("expected %q to be rejected", id)
+			}
+			continue
+		}
+		var got types.String
+		resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("subscription_id"), &got)...)
+		if resp.Diagnostics.HasError() || got.ValueString() != want {
+			t.Errorf("expected %q to import subscription %q, got %q: %v", id, want, got.ValueString(), resp.Diagnostics)
+		}
+	}
+}
+
+func TestSubscriptionTagsResource_merge(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeARM(t)
+	fake.setTags(fakeSubscriptionScope, map[string]string{"Owner": "policy"})
+
+	r := &SubscriptionTagsResource{}
+	var configureResp fwresource.ConfigureResponse
+	r.Configure(ctx, fwresource.ConfigureRequest{ProviderData: fake.providerData(fakeSubscriptionID)}, &configureResp)
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	plan := func(tags map[string]string) tfsdk.Plan {
+		t.Helper()
+		tagsValue, _ := types.MapValueFrom(ctx, types.StringType, tags)
+		plan := tfsdk.Plan{Schema: schemaResp.Schema}
+		if diags := plan.Set(ctx, &SubscriptionTagsResourceModel{
+			SubscriptionID:    types.StringValue(fakeSubscriptionID),
+			Tags:              tagsValue,
+			TagsAll:           types.MapUnknown(types.StringType),
+			Mode:              types.StringValue(tagsModeMerge),
+			InheritTags:       types.BoolValue(false),
+			PreferContainers:  types.BoolValue(false),
+			RemoveTags:        types.BoolValue(true),
+			RemoteInheritTags: types.BoolValue(false),
+		}); diags.HasError() {
+			t.Fatalf("building plan: %v", diags)
+		}
+		return plan
+	}
+	check := func(step string, want map[string]string) {
+		t.Helper()
+		if err := fake.checkTags(fakeSubscriptionScope, want)(nil); err != nil {
+			t.Fatalf("%s: %s", step, err)
+		}
+	}
+
+	created := fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan(map[string]string{"CostCenter": "1234", "Team": "finops"})}, &created)
+	if created.Diagnostics.HasError() {
+		t.Fatalf("creating resource: %v", created.Diagnostics)
+	}
+	check("create", map[string]string{"Owner": "policy", "CostCenter": "1234", "Team": "finops"})
+
+	// Keys dropped from tags are removed, tags set outside of Terraform stay.
+	updated := fwresource.UpdateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan(map[string]string{"CostCenter": "5678"}), State: created.State}, &updated)
+	if updated.Diagnostics.HasError() {
+		t.Fatalf("updating resource: %v", updated.Diagnostics)
+	}
+	check("update", map[string]string{"Owner": "policy", "CostCenter": "5678"})
+
+	// Unmanaged keys are not read back, so they never show up as drift.
+	fake.setTags(fakeSubscriptionScope, map[string]string{"Owner": "policy", "CostCenter": "5678", "Extra": "x"})
+	read := fwresource.ReadResponse{State: updated.State}
+	r.Read(ctx, fwresource.ReadRequest{State: updated.State}, &read)
+	var got SubscriptionTagsResourceModel
+	read.Diagnostics.Append(read.State.Get(ctx, &got)...)
+	if read.Diagnostics.HasError() {
+		t.Fatalf("reading resource: %v", read.Diagnostics)
+	}
+	gotTags, _ := tagsMap(ctx, got.Tags)
+	gotTagsAll, _ := tagsMap(ctx, got.TagsAll)
+	if want := map[string]string{"CostCenter": "5678"}; !maps.Equal(gotTags, want) || !maps.Equal(gotTagsAll, want) {
+		t.Fatalf("expected tags and tags_all %v, got %v and %v", want, gotTags, gotTagsAll)
+	}
+
+	var deleted fwresource.DeleteResponse
+	r.Delete(ctx, fwresource.DeleteRequest{State: read.State}, &deleted)
+	if deleted.Diagnostics.HasError() {
+		t.Fatalf("deleting resource: %v", deleted.Diagnostics)
+	}
+	check("delete", map[string]string{"Owner": "policy", "Extra": "x"})
+}
+
+func testAccSubscriptionTagsResourceConfig(tags string) string {
+	return fmt.Sprintf(`
+resource "azurex_subscription_tags" "test" {
+  tags = {
+    %s
+  }
+}
+`, tags)
+}
+
+func testAccSubscriptionTagsResourceMergeConfig(tags string) string {
+	return fmt.Sprintf(`
+resource "azurex_subscription_tags" "test" {
+  mode = "merge"
+
+  tags = {
+    %s
+  }
+}
+`, tags)
+}
+
+// checkTags verifies the tags the fake server holds for scope.
+func (f *fakeARM) checkTags(scope string, want map[string]string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		if got := f.getTagsAt(scope); !maps.Equal(got, want) {
+			return fmt.Errorf("expected tags %v at %s, got %v", want, scope, got)
+		}
+		return nil
+	}
+}
+
+// checkTagInheritance verifies the tag inheritance setting the fake server
+// holds for scope.
+func (f *fakeARM) checkTagInheritance(scope string, enabled bool, preferContainerTags bool) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		gotEnabled, gotPrefer := f.tagInheritanceAt(scope)
+		if gotEnabled != enabled || gotPrefer != preferContainerTags {
+			return fmt.Errorf("expected tag inheritance enabled=%t prefer_container_tags=%t at %s, got enabled=%t prefer_container_tags=%t",
+				enabled, preferContainerTags, scope, gotEnabled, gotPrefer)
+		}
+		return nil
+	}
+}
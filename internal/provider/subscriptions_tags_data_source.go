@@ -0,0 +1,179 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armsubscriptions"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SubscriptionsTagsDataSource{}
+
+func NewSubscriptionsTagsDataSource() datasource.DataSource {
+	return &SubscriptionsTagsDataSource{}
+}
+
+// SubscriptionsTagsDataSource defines the data source implementation.
+type SubscriptionsTagsDataSource struct {
+	SubscriptionsClient *armsubscriptions.Client
+	TagsClient          *armresources.TagsClient
+}
+
+// SubscriptionsTagsDataSourceModel describes the data source data model.
+type SubscriptionsTagsDataSourceModel struct {
+	Subscriptions types.List `tfsdk:"subscriptions"`
+}
+
+// subscriptionTagsModel is a single entry of subscriptions.
+type subscriptionTagsModel struct {
+	SubscriptionID types.String `tfsdk:"subscription_id"`
+	DisplayName    types.String `tfsdk:"display_name"`
+	State          types.String `tfsdk:"state"`
+	Tags           types.Map    `tfsdk:"tags"`
+}
+
+var subscriptionTagsAttrTypes = map[string]attr.Type{
+	"subscription_id": types.StringType,
+	"display_name":    types.StringType,
+	"state":           types.StringType,
+	"tags":            types.MapType{ElemType: types.StringType},
+}
+
+func (d *SubscriptionsTagsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_subscriptions_tags"
+}
+
+func (d *SubscriptionsTagsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the tags of every subscription visible to the provider credentials. Disabled and deleted subscriptions are skipped.",
+
+		Attributes: map[string]schema.Attribute{
+			"subscriptions": schema.ListNestedAttribute{
+				MarkdownDescription: "Subscriptions and their tags",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"subscription_id": schema.StringAttribute{
+							MarkdownDescription: "ID of the subscription",
+							Computed:            true,
+						},
+						"display_name": schema.StringAttribute{
+							MarkdownDescription: "Display name of the subscription",
+							Computed:            true,
+						},
+						"state": schema.StringAttribute{
+							MarkdownDescription: "State of the subscription, e.g. `Enabled`",
+							Computed:            true,
+						},
+						"tags": schema.MapAttribute{
+							MarkdownDescription: "Tags set on the subscription",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *SubscriptionsTagsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(AzurexContext)
+	if !ok {
+		resp.Diagnostics.AddError("unable to obtain provider data", "provider data not available")
+		return
+	}
+
+	subClient, err := armsubscriptions.NewClient(data.IdentityCreds, data.ClientOptions)
+	if err != nil {
+		resp.Diagnostics.AddError("unable to configure subscription client", fmt.Sprintf("got: %s", err.Error()))
+		return
+	}
+	d.SubscriptionsClient = subClient
+
+	tagsClient, err := armresources.NewTagsClient(data.SubscriptionID, data.IdentityCreds, data.ClientOptions)
+	if err != nil {
+		resp.Diagnostics.AddError("unable to configure tags client", fmt.Sprintf("got: %s", err.Error()))
+		return
+	}
+	d.TagsClient = tagsClient
+}
+
+func (d *SubscriptionsTagsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SubscriptionsTagsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	subscriptions := make([]subscriptionTagsModel, 0)
+
+	pager := d.SubscriptionsClient.NewListPager(nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Error listing subscriptions", err.Error())
+			return
+		}
+
+		for _, subscription := range page.Value {
+			if subscription == nil || subscription.SubscriptionID == nil {
+				continue
+			}
+
+			var state string
+			if subscription.State != nil {
+				state = string(*subscription.State)
+			}
+			if state == string(armsubscriptions.SubscriptionStateDisabled) || state == string(armsubscriptions.SubscriptionStateDeleted) {
+				tflog.Debug(ctx, fmt.Sprintf("skipping %s subscription %s", state, *subscription.SubscriptionID))
+				continue
+			}
+
+			tags, err := readTags(ctx, d.TagsClient, fmt.Sprintf("/subscriptions/%s", *subscription.SubscriptionID))
+			if err != nil {
+				resp.Diagnostics.AddError("Error reading subscription tags",
+					fmt.Sprintf("subscription %s: %s", *subscription.SubscriptionID, err.Error()))
+				return
+			}
+
+			tagsValue, diags := types.MapValueFrom(ctx, types.StringType, tags)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			subscriptions = append(subscriptions, subscriptionTagsModel{
+				SubscriptionID: types.StringValue(*subscription.SubscriptionID),
+				DisplayName:    types.StringPointerValue(subscription.DisplayName),
+				State:          types.StringValue(state),
+				Tags:           tagsValue,
+			})
+		}
+	}
+
+	subscriptionsValue, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: subscriptionTagsAttrTypes}, subscriptions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Subscriptions = subscriptionsValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
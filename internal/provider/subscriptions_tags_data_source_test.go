@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSubscriptionsTagsDataSource(t *testing.T) {
+	skipWithoutTerraform(t)
+	fake := newFakeARM(t)
+	fake.addSubscription("11111111-1111-1111-1111-111111111111")
+	fake.setTags(fakeSubscriptionScope, map[string]string{"Owner": "platform"})
+	fake.setTags("/subscriptions/11111111-1111-1111-1111-111111111111", map[string]string{"Owner": "data", "CostCenter": "42"})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fake.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+data "azurex_subscriptions_tags" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.azurex_subscriptions_tags.test", "subscriptions.#", "2"),
+					resource.TestCheckResourceAttr("data.azurex_subscriptions_tags.test", "subscriptions.0.subscription_id", fakeSubscriptionID),
+					resource.TestCheckResourceAttr("data.azurex_subscriptions_tags.test", "subscriptions.0.tags.Owner", "platform"),
+					resource.TestCheckResourceAttr("data.azurex_subscriptions_tags.test", "subscriptions.1.state", "Enabled"),
+					resource.TestCheckResourceAttr("data.azurex_subscriptions_tags.test", "subscriptions.1.tags.%", "2"),
+					resource.TestCheckResourceAttr("data.azurex_subscriptions_tags.test", "subscriptions.1.tags.CostCenter", "42"),
+				),
+			},
+		},
+	})
+}
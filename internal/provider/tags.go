@@ -0,0 +1,315 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// tagsModeAuthoritative replaces every tag at the scope with the
+	// configured set.
+	tagsModeAuthoritative = "authoritative"
+	// tagsModeMerge only adds, updates and removes the configured keys,
+	// leaving tags owned by other teams or Azure Policy untouched.
+	tagsModeMerge = "merge"
+)
+
+var tagsModes = []string{tagsModeAuthoritative, tagsModeMerge}
+
+// armScopePattern matches an ARM resource ID such as
+// /subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}.
+var armScopePattern = regexp.MustCompile(`^(/[^/]+)+/?$`)
+
+// readTags returns the tags currently set at scope.
+func readTags(ctx context.Context, client *armresources.TagsClient, scope string) (map[string]string, error) {
+	resp, err := client.GetAtScope(ctx, scope, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make(map[string]string)
+	if resp.Properties != nil && resp.Properties.Tags != nil {
+		for k, v := range resp.Properties.Tags {
+			if v != nil {
+				tags[k] = *v
+			}
+		}
+	}
+	return tags, nil
+}
+
+// writeTags applies tags at scope. In merge mode the keys present in
+// previous but no longer in tags are removed, everything else is left alone.
+func writeTags(ctx context.Context, client *armresources.TagsClient, scope string, mode string, tags map[string]string, previous map[string]string) error {
+	if mode != tagsModeMerge {
+		_, err := client.CreateOrUpdateAtScope(ctx, scope, armresources.TagsResource{
+			Properties: &armresources.Tags{
+				Tags: toAzureTags(tags),
+			},
+		}, nil)
+		return err
+	}
+
+	if len(tags) > 0 {
+		operation := armresources.TagsPatchOperationMerge
+		_, err := client.UpdateAtScope(ctx, scope, armresources.TagsPatchResource{
+			Operation: &operation,
+			Properties: &armresources.Tags{
+				Tags: toAzureTags(tags),
+			},
+		}, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	var removed []string
+	for k := range previous {
+		if _, ok := tags[k]; !ok {
+			removed = append(removed, k)
+		}
+	}
+	return deleteTags(ctx, client, scope, removed)
+}
+
+// deleteTags removes keys from scope without touching any other tag.
+func deleteTags(ctx context.Context, client *armresources.TagsClient, scope string, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	// The Delete operation only removes name/value pairs that match, so look
+	// up the current values rather than trusting what is in state.
+	current, err := readTags(ctx, client, scope)
+	if err != nil {
+		return fmt.Errorf("reading current tags: %w", err)
+	}
+
+	remove := make(map[string]string)
+	for _, k := range keys {
+		if v, ok := current[k]; ok {
+			remove[k] = v
+		}
+	}
+	if len(remove) == 0 {
+		return nil
+	}
+
+	operation := armresources.TagsPatchOperationDelete
+	_, err = client.UpdateAtScope(ctx, scope, armresources.TagsPatchResource{
+		Operation: &operation,
+		Properties: &armresources.Tags{
+			Tags: toAzureTags(remove),
+		},
+	}, nil)
+	return err
+}
+
+// managedTags filters tags down to the keys in managed, used in merge mode so
+// tags set outside of Terraform never show up as drift.
+func managedTags(tags map[string]string, managed map[string]string) map[string]string {
+	filtered := make(map[string]string)
+	for k := range managed {
+		if v, ok := tags[k]; ok {
+			filtered[k] = v
+		}
+	}
+	return filtered
+}
+
+// tagFilter ignores tags added outside of Terraform, e.g. by Cost Management
+// tag inheritance or Azure Policy, by key or key prefix. Azure tag keys are
+// case-insensitive so matching is too.
+type tagFilter struct {
+	keys     []string
+	prefixes []string
+}
+
+func (f tagFilter) ignored(key string) bool {
+	for _, k := range f.keys {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+	for _, prefix := range f.prefixes {
+		if len(key) >= len(prefix) && strings.EqualFold(key[:len(prefix)], prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// filter drops the ignored keys from tags unless they are in keep, so a
+// configured tag is still tracked even when it matches the filter.
+func (f tagFilter) filter(tags map[string]string, keep map[string]string) map[string]string {
+	filtered := make(map[string]string)
+	for k, v := range tags {
+		if _, ok := keep[k]; ok || !f.ignored(k) {
+			filtered[k] = v
+		}
+	}
+	return filtered
+}
+
+// preserve adds the ignored keys in current to tags, so an authoritative
+// write does not remove them.
+func (f tagFilter) preserve(tags map[string]string, current map[string]string) map[string]string {
+	preserved := make(map[string]string, len(tags))
+	for k, v := range current {
+		if f.ignored(k) {
+			preserved[k] = v
+		}
+	}
+	for k, v := range tags {
+		preserved[k] = v
+	}
+	return preserved
+}
+
+// empty reports whether f ignores nothing.
+func (f tagFilter) empty() bool {
+	return len(f.keys) == 0 && len(f.prefixes) == 0
+}
+
+// with returns a filter ignoring the keys and prefixes of both f and other.
+func (f tagFilter) with(other tagFilter) tagFilter {
+	return tagFilter{
+		keys:     append(append([]string{}, f.keys...), other.keys...),
+		prefixes: append(append([]string{}, f.prefixes...), other.prefixes...),
+	}
+}
+
+// tagsSettings are the provider default_tags and ignore_tags every tag
+// managing resource applies on top of its own tags.
+type tagsSettings struct {
+	defaults map[string]string
+	ignore   tagFilter
+}
+
+// withIgnore returns s with the resource level filter f added.
+func (s tagsSettings) withIgnore(f tagFilter) tagsSettings {
+	return tagsSettings{defaults: s.defaults, ignore: s.ignore.with(f)}
+}
+
+// all returns the effective tags, default_tags overridden by tags, without
+// ignored keys. Keys set in tags are never ignored.
+func (s tagsSettings) all(tags map[string]string) map[string]string {
+	merged := make(map[string]string, len(s.defaults)+len(tags))
+	for k, v := range s.defaults {
+		merged[k] = v
+	}
+	for k, v := range tags {
+		merged[k] = v
+	}
+	return s.ignore.filter(merged, tags)
+}
+
+// fromRemote splits the tags read from Azure into tags_all and tags. Keys
+// only present because of default_tags are left out of tags so they never
+// show up as drift there. stateTagsAll is nil for state written before
+// tags_all existed.
+func (s tagsSettings) fromRemote(remote map[string]string, mode string, stateTags map[string]string, stateTagsAll map[string]string) (tags map[string]string, tagsAll map[string]string) {
+	tagsAll = s.ignore.filter(remote, stateTags)
+	if mode == tagsModeMerge {
+		managed := stateTagsAll
+		if managed == nil {
+			managed = stateTags
+		}
+		tagsAll = managedTags(tagsAll, managed)
+	}
+
+	tags = make(map[string]string)
+	for k, v := range tagsAll {
+		_, configured := stateTags[k]
+		if d, isDefault := s.defaults[k]; configured || !isDefault || d != v {
+			tags[k] = v
+		}
+	}
+	return tags, tagsAll
+}
+
+// applyTagsAll writes tagsAll at scope. In authoritative mode the ignored
+// tags currently set are carried over so they survive the replace.
+func applyTagsAll(ctx context.Context, client *armresources.TagsClient, scope string, mode string, settings tagsSettings, tagsAll map[string]string, previous map[string]string) error {
+	if mode != tagsModeMerge && !settings.ignore.empty() {
+		current, err := readTags(ctx, client, scope)
+		if err != nil {
+			return err
+		}
+		tagsAll = settings.ignore.preserve(tagsAll, current)
+	}
+	return writeTags(ctx, client, scope, mode, tagsAll, previous)
+}
+
+// planTagsAll sets tags_all in the plan to the effective tags, so the plan
+// shows what will actually be written.
+func planTagsAll(ctx context.Context, settings tagsSettings, plan *tfsdk.Plan) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var tagsValue types.Map
+	diags.Append(plan.GetAttribute(ctx, path.Root("tags"), &tagsValue)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if tagsValue.IsUnknown() {
+		diags.Append(plan.SetAttribute(ctx, path.Root("tags_all"), types.MapUnknown(types.StringType))...)
+		return diags
+	}
+
+	tags := make(map[string]string)
+	if !tagsValue.IsNull() {
+		diags.Append(tagsValue.ElementsAs(ctx, &tags, false)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	tagsAll, d := types.MapValueFrom(ctx, types.StringType, settings.all(tags))
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	diags.Append(plan.SetAttribute(ctx, path.Root("tags_all"), tagsAll)...)
+	return diags
+}
+
+// tagsMap converts a framework map into a Go map, returning nil when m is
+// null or unknown.
+func tagsMap(ctx context.Context, m types.Map) (map[string]string, diag.Diagnostics) {
+	if m.IsNull() || m.IsUnknown() {
+		return nil, nil
+	}
+	tags := make(map[string]string)
+	diags := m.ElementsAs(ctx, &tags, false)
+	return tags, diags
+}
+
+// lastAppliedTags returns the tags written by the last apply, tags_all or
+// tags for state written before tags_all existed.
+func lastAppliedTags(ctx context.Context, tags types.Map, tagsAll types.Map) (map[string]string, diag.Diagnostics) {
+	if tagsAll.IsNull() {
+		return tagsMap(ctx, tags)
+	}
+	return tagsMap(ctx, tagsAll)
+}
+
+func toAzureTags(tags map[string]string) map[string]*string {
+	azureTags := make(map[string]*string)
+	for k, v := range tags {
+		value := v
+		azureTags[k] = &value
+	}
+	return azureTags
+}
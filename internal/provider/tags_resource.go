@@ -0,0 +1,296 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	subscriptionSettings "github.com/ekristen/terraform-provider-azurex/internal/azure/subscriptions"
+)
+
+// tagsResourcePath is appended to a scope to form the ID of its tags.
+const tagsResourcePath = "/providers/Microsoft.Resources/tags/default"
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &TagsResource{}
+var _ resource.ResourceWithImportState = &TagsResource{}
+var _ resource.ResourceWithModifyPlan = &TagsResource{}
+
+func NewTagsResource() resource.Resource {
+	return &TagsResource{}
+}
+
+// TagsResource defines the resource implementation.
+type TagsResource struct {
+	TagsClient   *armresources.TagsClient
+	TagsSettings tagsSettings
+}
+
+// TagsResourceModel describes the resource data model.
+type TagsResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Scope   types.String `tfsdk:"scope"`
+	Tags    types.Map    `tfsdk:"tags"`
+	TagsAll types.Map    `tfsdk:"tags_all"`
+	Mode    types.String `tfsdk:"mode"`
+}
+
+func (r *TagsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tags"
+}
+
+func (r *TagsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Tags on any ARM scope, such as a resource group, a resource or a management group, without managing the resource itself",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the tags resource, the scope followed by `/providers/Microsoft.Resources/tags/default`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"scope": schema.StringAttribute{
+				MarkdownDescription: "Resource ID of the scope to tag, e.g. `/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example`. Changing this forces a new resource to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(armScopePattern, "must be an ARM resource ID starting with /"),
+				},
+			},
+			"tags": schema.MapAttribute{
+				MarkdownDescription: "Tags to apply to the scope",
+				ElementType:         types.StringType,
+				Required:            true,
+			},
+			"tags_all": schema.MapAttribute{
+				MarkdownDescription: "Tags applied to the scope, including the provider `default_tags`",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"mode": schema.StringAttribute{
+				MarkdownDescription: "How tags are applied, either `authoritative` to replace every tag on the scope or `merge` to only manage the keys set in `tags`. Defaults to `authoritative`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(tagsModeAuthoritative),
+				Validators: []validator.String{
+					stringvalidator.OneOf(tagsModes...),
+				},
+			},
+		},
+	}
+}
+
+func (r *TagsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(AzurexContext)
+	if !ok {
+		resp.Diagnostics.AddError("unable to obtain provider data", "provider data not available")
+		return
+	}
+
+	tagsClient, err := armresources.NewTagsClient(data.SubscriptionID, data.IdentityCreds, data.ClientOptions)
+	if err != nil {
+		resp.Diagnostics.AddError("unable to configure tags client", fmt.Sprintf("got: %s", err.Error()))
+		return
+	}
+	r.TagsClient = tagsClient
+
+	r.TagsSettings = data.TagsSettings
+}
+
+func (r *TagsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(planTagsAll(ctx, r.TagsSettings, &resp.Plan)...)
+}
+
+func (r *TagsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *TagsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "creating tags resource")
+
+	tfTags := make(map[string]string)
+	resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tfTags, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tagsAll := r.TagsSettings.all(tfTags)
+	scope := strings.TrimSuffix(data.Scope.ValueString(), "/")
+	if err := applyTagsAll(ctx, r.TagsClient, scope, data.Mode.ValueString(), r.TagsSettings, tagsAll, nil); err != nil {
+		resp.Diagnostics.AddError("Error setting tags", fmt.Sprintf("scope %s: %s", scope, err.Error()))
+		return
+	}
+
+	tagsAllValue, diags := types.MapValueFrom(ctx, types.StringType, tagsAll)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.TagsAll = tagsAllValue
+	data.ID = types.StringValue(scope + tagsResourcePath)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TagsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *TagsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stateTags, diags := tagsMap(ctx, data.Tags)
+	resp.Diagnostics.Append(diags...)
+	stateTagsAll, diags := tagsMap(ctx, data.TagsAll)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	scope := strings.TrimSuffix(data.Scope.ValueString(), "/")
+	remote, err := readTags(ctx, r.TagsClient, scope)
+	if subscriptionSettings.IsNotFound(err) {
+		tflog.Debug(ctx, fmt.Sprintf("scope %s no longer exists, removing from state", scope))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading tags", fmt.Sprintf("scope %s: %s", scope, err.Error()))
+		return
+	}
+
+	tags, tagsAll := r.TagsSettings.fromRemote(remote, data.Mode.ValueString(), stateTags, stateTagsAll)
+
+	tagsValue, diags := types.MapValueFrom(ctx, types.StringType, tags)
+	resp.Diagnostics.Append(diags...)
+	tagsAllValue, diags := types.MapValueFrom(ctx, types.StringType, tagsAll)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Tags = tagsValue
+	data.TagsAll = tagsAllValue
+	data.ID = types.StringValue(scope + tagsResourcePath)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TagsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *TagsResourceModel
+	var oldData *TagsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &oldData)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updating tags resource")
+
+	tfTags := make(map[string]string)
+	resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tfTags, false)...)
+	previous, diags := lastAppliedTags(ctx, oldData.Tags, oldData.TagsAll)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tagsAll := r.TagsSettings.all(tfTags)
+	scope := strings.TrimSuffix(data.Scope.ValueString(), "/")
+	if err := applyTagsAll(ctx, r.TagsClient, scope, data.Mode.ValueString(), r.TagsSettings, tagsAll, previous); err != nil {
+		resp.Diagnostics.AddError("Error updating tags", fmt.Sprintf("scope %s: %s", scope, err.Error()))
+		return
+	}
+
+	tagsAllValue, diags := types.MapValueFrom(ctx, types.StringType, tagsAll)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.TagsAll = tagsAllValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TagsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *TagsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "deleting tags resource")
+
+	// In merge mode only the managed keys are removed
+	previous, diags := lastAppliedTags(ctx, data.Tags, data.TagsAll)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	scope := strings.TrimSuffix(data.Scope.ValueString(), "/")
+	err := applyTagsAll(ctx, r.TagsClient, scope, data.Mode.ValueString(), r.TagsSettings, map[string]string{}, previous)
+	if subscriptionSettings.IsNotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error removing tags", fmt.Sprintf("scope %s: %s", scope, err.Error()))
+		return
+	}
+}
+
+// ImportState accepts either the scope or the ID of its tags, e.g.
+// /subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Resources/tags/default.
+// Imported tags are managed authoritatively.
+func (r *TagsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	scope := strings.TrimSuffix(req.ID, "/")
+	if strings.HasSuffix(strings.ToLower(scope), strings.ToLower(tagsResourcePath)) {
+		scope = scope[:len(scope)-len(tagsResourcePath)]
+	}
+	if !armScopePattern.MatchString(scope) {
+		resp.Diagnostics.AddError("Invalid import ID",
+			fmt.Sprintf("Expected an ARM scope or {scope}%s, got %q.", tagsResourcePath, req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), scope+tagsResourcePath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("scope"), scope)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("mode"), tagsModeAuthoritative)...)
+}
@@ -0,0 +1,141 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"maps"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccTagsResource(t *testing.T) {
+	skipWithoutTerraform(t)
+	fake := newFakeARM(t)
+	scope := fakeSubscriptionScope + "/resourceGroups/example"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fake.providerFactories(),
+		CheckDestroy:             fake.checkTags(scope, map[string]string{}),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + testAccTagsResourceConfig(scope, tagsModeAuthoritative, `Environment = "test"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("azurex_tags.test", "id", scope+tagsResourcePath),
+					resource.TestCheckResourceAttr("azurex_tags.test", "tags.%", "1"),
+					fake.checkTags(scope, map[string]string{"Environment": "test"}),
+				),
+			},
+			{
+				Config: fake.providerConfig() + testAccTagsResourceConfig(scope, tagsModeAuthoritative, `Environment = "prod"`),
+				Check:  fake.checkTags(scope, map[string]string{"Environment": "prod"}),
+			},
+			{
+				ResourceName:      "azurex_tags.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					fake.setTags(scope, map[string]string{"Environment": "prod", "Rogue": "true"})
+				},
+				Config: fake.providerConfig() + testAccTagsResourceConfig(scope, tagsModeAuthoritative, `Environment = "prod"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("azurex_tags.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: fake.checkTags(scope, map[string]string{"Environment": "prod"}),
+			},
+		},
+	})
+}
+
+func TestAccTagsResource_merge(t *testing.T) {
+	skipWithoutTerraform(t)
+	fake := newFakeARM(t)
+	scope := "/providers/Microsoft.Management/managementGroups/example"
+	fake.setTags(scope, map[string]string{"Owner": "policy"})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fake.providerFactories(),
+		CheckDestroy:             fake.checkTags(scope, map[string]string{"Owner": "policy"}),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + testAccTagsResourceConfig(scope, tagsModeMerge, `CostCenter = "1234"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("azurex_tags.test", "tags.%", "1"),
+					fake.checkTags(scope, map[string]string{"Owner": "policy", "CostCenter": "1234"}),
+				),
+			},
+			{
+				PreConfig: func() {
+					fake.setTags(scope, map[string]string{"Owner": "policy", "CostCenter": "1234", "Extra": "x"})
+				},
+				Config: fake.providerConfig() + testAccTagsResourceConfig(scope, tagsModeMerge, `CostCenter = "1234"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				PreConfig: func() {
+					fake.setTags(scope, map[string]string{"Owner": "policy", "CostCenter": "1234"})
+				},
+				Config: fake.providerConfig() + testAccTagsResourceConfig(scope, tagsModeMerge, `CostCenter = "1234"`),
+			},
+		},
+	})
+}
+
+func TestTagsSettings(t *testing.T) {
+	settings := tagsSettings{
+		defaults: map[string]string{"CostCenter": "1234", "Owner": "platform", "hidden-owner": "x"},
+		ignore:   tagFilter{prefixes: []string{"hidden-"}},
+	}
+
+	tags := map[string]string{"Owner": "finops", "Environment": "test"}
+	if got, want := settings.all(tags), map[string]string{"CostCenter": "1234", "Owner": "finops", "Environment": "test"}; !maps.Equal(got, want) {
+		t.Fatalf("expected tags_all %v, got %v", want, got)
+	}
+
+	remote := map[string]string{"CostCenter": "1234", "Owner": "finops", "Environment": "test", "hidden-policy": "audit"}
+	gotTags, gotAll := settings.fromRemote(remote, tagsModeAuthoritative, tags, nil)
+	if !maps.Equal(gotTags, tags) {
+		t.Fatalf("expected tags %v, got %v", tags, gotTags)
+	}
+	if want := settings.all(tags); !maps.Equal(gotAll, want) {
+		t.Fatalf("expected tags_all %v, got %v", want, gotAll)
+	}
+
+	// A default tag changed outside of Terraform shows up in tags.
+	remote["CostCenter"] = "5678"
+	gotTags, _ = settings.fromRemote(remote, tagsModeAuthoritative, tags, nil)
+	if want := map[string]string{"CostCenter": "5678", "Owner": "finops", "Environment": "test"}; !maps.Equal(gotTags, want) {
+		t.Fatalf("expected tags %v, got %v", want, gotTags)
+	}
+
+	// Merge mode only reports the keys written by the last apply.
+	remote["Extra"] = "x"
+	_, gotAll = settings.fromRemote(remote, tagsModeMerge, tags, map[string]string{"CostCenter": "1234", "Owner": "finops", "Environment": "test"})
+	if want := map[string]string{"CostCenter": "5678", "Owner": "finops", "Environment": "test"}; !maps.Equal(gotAll, want) {
+		t.Fatalf("expected tags_all %v, got %v", want, gotAll)
+	}
+}
+
+func testAccTagsResourceConfig(scope string, mode string, tags string) string {
+	return fmt.Sprintf(`
+resource "azurex_tags" "test" {
+  scope = %q
+  mode  = %q
+
+  tags = {
+    %s
+  }
+}
+`, scope, mode, tags)
+}